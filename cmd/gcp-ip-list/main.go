@@ -6,14 +6,23 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/netip"
 	"os"
 	"regexp"
 	"slices"
+	"strings"
 
 	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
 	"github.com/mark-adams/gcp-ip-list/pkg/output"
 )
 
+const (
+	reportUtilization = "utilization"
+	reportOverlaps    = "overlaps"
+	reportReserved    = "reserved"
+	reportCost        = "cost"
+)
+
 var (
 	version = "dev"
 
@@ -25,6 +34,25 @@ var (
 	public  = flag.Bool("public", false, "Include public IPs only")
 	private = flag.Bool("private", false, "Include private IPs only")
 
+	ipv4 = flag.Bool("ipv4", false, "Include IPv4 addresses only")
+	ipv6 = flag.Bool("ipv6", false, "Include IPv6 addresses only")
+
+	privateRanges = flag.String("private-ranges", "", "Comma-separated list of public ranges used privately (i.e. 11.0.0.0/8) that should be treated as private")
+	detectPUPI    = flag.Bool("detect-pupi", false, "Treat addresses in subnets that use public IP ranges as private")
+
+	googleRanges = flag.String("google-ranges", "", "Comma-separated list of paths to Google's published IP range files (cloud.json, goog.json) used to flag BYOIP addresses")
+	byoip        = flag.Bool("byoip", false, "Include BYOIP addresses (public addresses outside of Google's IP ranges) only, requires -google-ranges")
+
+	warnUnresolved = flag.Bool("warn-unresolved", false, "Print a warning for every address reference (i.e. Cloud NAT addresses) that wasn't found in the scope")
+
+	gateways = flag.Bool("gateways", false, "Include Gateway API gateways (gateway.networking.k8s.io/Gateway), which Cloud Asset Inventory may not support searching")
+
+	ranges = flag.Bool("ranges", false, "Include allocated IP ranges (i.e. GKE pod and service ranges or instance alias IP ranges) in CIDR notation")
+
+	report = flag.String("report", "", "Generate a report instead of listing addresses (utilization, overlaps, reserved, cost)")
+
+	pricing = flag.String("pricing", "", "Path to a YAML or JSON file with the hourly price of external IPv4 addresses in each cost category, required by the cost report")
+
 	showVersion = flag.Bool("version", false, "Display the current version")
 )
 
@@ -49,26 +77,151 @@ func main() {
 		log.Fatalf("error: cannot specify both public and private flags")
 	}
 
-	formatters := output.GetFormatters()
+	if *ipv4 && *ipv6 {
+		log.Fatalf("error: cannot specify both ipv4 and ipv6 flags")
+	}
+
+	options := gcp.Options{
+		IncludeRanges:                   *ranges,
+		DetectPrivatelyUsedPublicRanges: *detectPUPI,
+		IncludeKubernetesGateways:       *gateways,
+	}
+
+	if *privateRanges != "" {
+		for _, cidr := range strings.Split(*privateRanges, ",") {
+			prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+			if err != nil {
+				log.Fatalf("error: invalid private range: %s", cidr)
+			}
+			options.PrivateRanges = append(options.PrivateRanges, prefix)
+		}
+	}
+
+	var formatter output.FormatterFunc
+	var utilizationFormatter output.UtilizationFormatterFunc
+	var overlapFormatter output.OverlapFormatterFunc
+	var costFormatter output.CostFormatterFunc
+	var costPricing *gcp.Pricing
+
+	switch *report {
+	case "":
+		formatter = output.GetFormatters()[*format]
+		if formatter == nil {
+			log.Fatalf("error: invalid formatter: %s", *format)
+		}
+	case reportUtilization:
+		utilizationFormatter = output.GetUtilizationFormatters()[*format]
+		if utilizationFormatter == nil {
+			log.Fatalf("error: invalid formatter for %s report: %s", *report, *format)
+		}
+
+		// Utilization is calculated from the subnet ranges
+		options.IncludeRanges = true
+	case reportOverlaps:
+		overlapFormatter = output.GetOverlapFormatters()[*format]
+		if overlapFormatter == nil {
+			log.Fatalf("error: invalid formatter for %s report: %s", *report, *format)
+		}
+	case reportReserved:
+		formatter = output.GetReservedFormatters()[*format]
+		if formatter == nil {
+			log.Fatalf("error: invalid formatter for %s report: %s", *report, *format)
+		}
+
+		options.IncludeReserved = true
+	case reportCost:
+		costFormatter = output.GetCostFormatters()[*format]
+		if costFormatter == nil {
+			log.Fatalf("error: invalid formatter for %s report: %s", *report, *format)
+		}
+
+		if *pricing == "" {
+			log.Fatalf("error: %s report requires pricing", *report)
+		}
+
+		var err error
+		costPricing, err = gcp.LoadPricing(*pricing)
+		if err != nil {
+			log.Fatalf("error: failed to load pricing: %s", err)
+		}
+
+		// Idle static addresses are billed too
+		options.IncludeReserved = true
+	default:
+		log.Fatalf("error: invalid report: %s", *report)
+	}
 
-	formatter := formatters[*format]
-	if formatter == nil {
-		log.Fatalf("error: invalid formatter: %s", *format)
+	if *googleRanges != "" {
+		for _, path := range strings.Split(*googleRanges, ",") {
+			loadedRanges, err := gcp.LoadGoogleRanges(strings.TrimSpace(path))
+			if err != nil {
+				log.Fatalf("error: failed to load google ip ranges: %s", err)
+			}
+			options.GoogleRanges = append(options.GoogleRanges, loadedRanges...)
+		}
+	} else if *byoip {
+		log.Fatalf("error: byoip flag requires google-ranges")
 	}
 
 	ctx := context.Background()
 
-	addresses, err := gcp.GetAllAddressesFromAssetInventory(ctx, *scope)
+	if *report == reportOverlaps {
+		overlaps, err := gcp.GetOverlappingRangesFromAssetInventory(ctx, *scope)
+		if err != nil {
+			log.Fatalf("error: failed to get overlapping ranges: %s", err)
+		}
+
+		if err := overlapFormatter(os.Stdout, overlaps); err != nil {
+			log.Fatalf("error writing output: %s", err)
+		}
+		return
+	}
+
+	addresses, err := gcp.GetAllAddressesFromAssetInventoryWithOptions(ctx, *scope, options)
 	if err != nil {
 		log.Fatalf("error: failed to get addresses: %s", err)
 	}
 
+	if *warnUnresolved {
+		for _, addr := range gcp.FilterUnresolvedReferences(addresses) {
+			log.Printf("warning: %s references %s which wasn't found, it may be in a project outside of the scope", addr.ResourceName, addr.Reference)
+		}
+	}
+
+	if *report == reportUtilization {
+		if err := utilizationFormatter(os.Stdout, gcp.GetSubnetUtilization(addresses)); err != nil {
+			log.Fatalf("error writing output: %s", err)
+		}
+		return
+	}
+
+	if *report == reportCost {
+		if err := costFormatter(os.Stdout, gcp.GetAddressCosts(addresses, costPricing)); err != nil {
+			log.Fatalf("error writing output: %s", err)
+		}
+		return
+	}
+
+	if *report == reportReserved {
+		addresses = gcp.FilterReservedAddresses(addresses)
+	}
+
 	if *public {
 		addresses = gcp.FilterPublicAddresses(addresses)
 	} else if *private {
 		addresses = gcp.FilterPrivateAddresses(addresses)
 	}
 
+	if *ipv4 {
+		addresses = gcp.FilterIPv4Addresses(addresses)
+	} else if *ipv6 {
+		addresses = gcp.FilterIPv6Addresses(addresses)
+	}
+
+	if *byoip {
+		addresses = gcp.FilterBYOIPAddresses(addresses)
+	}
+
 	// Sort the output by the address type (descending), then by resource type, then by resource name
 	// (chosen somewhat arbitrarily)
 	slices.SortFunc(addresses, func(a, b *gcp.Address) int {
@@ -3,8 +3,9 @@ package gcp
 import (
 	"context"
 	"fmt"
-	"net"
+	"net/netip"
 	"slices"
+	"time"
 
 	asset "cloud.google.com/go/asset/apiv1"
 	assetpb "cloud.google.com/go/asset/apiv1/assetpb"
@@ -27,43 +28,300 @@ const (
 	// We use this placeholder to flag these assets so we can normalize them to the actual IP address
 	// later. The output of the application should never show these.
 	AddressTypeReference = "reference"
+
+	// AddressTypeUnresolved refers to a reference whose resource wasn't found (i.e. a Cloud NAT address in a project
+	// outside of the scope). These have no address and are kept as a diagnostic with the self link of the missing
+	// resource in Reference.
+	AddressTypeUnresolved = "unresolved"
+)
+
+const (
+	// IPVersion4 refers to an IPv4 address or range
+	IPVersion4 = "ipv4"
+
+	// IPVersion6 refers to an IPv6 address or range
+	IPVersion6 = "ipv6"
+)
+
+const (
+	// AddressScopeGlobal refers to a global (i.e. anycast) address
+	AddressScopeGlobal = "global"
+
+	// AddressScopeRegional refers to an address that only exists within a single region
+	AddressScopeRegional = "regional"
+)
+
+const (
+	// RelationshipReservedBy refers to the static address resource reserving an IP address
+	RelationshipReservedBy = "reserved-by"
+
+	// RelationshipUsedBy refers to a resource using an IP address
+	RelationshipUsedBy = "used-by"
 )
 
+// RelatedResource is another resource sharing the IP address of an Address (i.e. the static address and forwarding rule
+// behind a Kubernetes service)
+type RelatedResource struct {
+	ResourceName string `json:"resource_name"`
+	ResourceType string `json:"asset_type"`
+	Relationship string `json:"relationship"`
+}
+
 type Address struct {
 	Address      string `json:"address"`
 	AddressType  string `json:"type"`
+	IPVersion    string `json:"ip_version"`
 	ResourceName string `json:"resource_name"`
 	ResourceType string `json:"asset_type"`
+
+	// Project, Location, DisplayName, Labels, CreateTime (RFC 3339), State and ParentFullResourceName are copied from
+	// the asset the address belongs to. Project is the project number (i.e. projects/123456).
+	Project                string            `json:"project,omitempty"`
+	Location               string            `json:"location,omitempty"`
+	DisplayName            string            `json:"display_name,omitempty"`
+	Labels                 map[string]string `json:"labels,omitempty"`
+	CreateTime             string            `json:"create_time,omitempty"`
+	State                  string            `json:"state,omitempty"`
+	ParentFullResourceName string            `json:"parent_full_resource_name,omitempty"`
+
+	// Classification is the fine-grained classification of the address (one of the Classification constants),
+	// AddressType is the public/private rollup of it
+	Classification string `json:"classification"`
+
+	// AddressScope is only set for static addresses and forwarding rules, which can be either global or regional
+	AddressScope string `json:"address_scope,omitempty"`
+
+	// Prefix is the allocated range in CIDR notation and is only set for ranges, in which case Address holds
+	// the network address of the range
+	Prefix string `json:"prefix,omitempty"`
+
+	// RangeName is the name of a named range (i.e. a subnet's secondary range) when known
+	RangeName string `json:"range_name,omitempty"`
+
+	// Network is the full resource name of the VPC network the address belongs to when known
+	Network string `json:"network,omitempty"`
+
+	// NetworkInterface, Subnetwork and AccessConfig describe the network interface (and for external addresses,
+	// the access config) of the Compute Engine instance the address is attached to and are only set for instances
+	NetworkInterface string `json:"network_interface,omitempty"`
+	Subnetwork       string `json:"subnetwork,omitempty"`
+	AccessConfig     string `json:"access_config,omitempty"`
+
+	// NetworkTier (PREMIUM or STANDARD) is only set for external instance and static addresses
+	NetworkTier string `json:"network_tier,omitempty"`
+
+	// AliasIPRanges holds the alias IP ranges (in CIDR notation) of the instance network interface the address is attached to
+	AliasIPRanges []string `json:"alias_ip_ranges,omitempty"`
+
+	// GoogleOwned, GoogleService and GoogleScope are only set when Google's published IP ranges are provided
+	// (see Options.GoogleRanges) and describe the published range containing a public address
+	GoogleOwned   bool   `json:"google_owned,omitempty"`
+	GoogleService string `json:"google_service,omitempty"`
+	GoogleScope   string `json:"google_scope,omitempty"`
+
+	// BYOIP is set for public addresses outside of Google's published IP ranges (bring your own IP)
+	// when they are provided
+	BYOIP bool `json:"byoip,omitempty"`
+
+	// Related holds the other resources sharing the IP address, which are deduplicated in favor of the most specific one
+	Related []RelatedResource `json:"related,omitempty"`
+
+	// Reference is the self link of the resource holding the IP address, as found on the referencing resource (i.e. a
+	// Cloud NAT's natIps), and is only set for unresolved references
+	Reference string `json:"reference,omitempty"`
+
+	// KubernetesCluster and KubernetesNamespace are only set for Kubernetes resources
+	KubernetesCluster   string `json:"kubernetes_cluster,omitempty"`
+	KubernetesNamespace string `json:"kubernetes_namespace,omitempty"`
+}
+
+// IsRange returns true if the address is an allocated range rather than a single IP
+func (a *Address) IsRange() bool {
+	return a.Prefix != ""
+}
+
+// IsReserved returns true if the address is a static address that is reserved but not attached to any resource
+func (a *Address) IsReserved() bool {
+	isStaticAddress := a.ResourceType == AssetTypeComputeAddress || a.ResourceType == AssetTypeComputeGlobalAddress
+	return isStaticAddress && !a.IsRange() && a.State == "RESERVED"
+}
+
+// AddressOrPrefix returns the range in CIDR notation for ranges or the IP for single addresses
+func (a *Address) AddressOrPrefix() string {
+	if a.IsRange() {
+		return a.Prefix
+	}
+	return a.Address
+}
+
+// Options controls which addresses are returned from the Cloud Asset Inventory API
+type Options struct {
+	// IncludeRanges includes allocated IP ranges (i.e. GKE pod and service ranges) in CIDR notation alongside single addresses
+	IncludeRanges bool
+
+	// IncludeReserved includes static addresses that are reserved but not attached to any resource
+	IncludeReserved bool
+
+	// PrivateRanges are public ranges that are used privately (PUPI). Public addresses within them are classified as
+	// ClassificationPrivatelyUsedPublic and treated as private.
+	PrivateRanges []netip.Prefix
+
+	// DetectPrivatelyUsedPublicRanges treats the public IPv4 ranges of subnets as PrivateRanges, fetching the subnets if needed
+	DetectPrivatelyUsedPublicRanges bool
+
+	// IncludeKubernetesGateways searches Gateway API gateways (gateway.networking.k8s.io/Gateway) in
+	// GetAllAddressesFromAssetInventoryWithOptions. They are left out by default since Cloud Asset Inventory doesn't list
+	// them as a searchable asset type.
+	IncludeKubernetesGateways bool
+
+	// GoogleRanges are Google's published IP ranges (see LoadGoogleRanges). When set, public addresses are annotated
+	// with whether they are inside Google-owned space or are BYOIP.
+	GoogleRanges []GoogleRange
 }
 
-// GetAllAddressesFromAssetInventory queries the Cloud Asset Inventory API and returns back IP addresses from all supported asset types
+// GetAllAddressesFromAssetInventory queries the Cloud Asset Inventory API and returns back IP addresses from all supported asset types,
+// except for Gateway API gateways which have to be enabled with Options.IncludeKubernetesGateways
 func GetAllAddressesFromAssetInventory(ctx context.Context, scope string, opts ...option.ClientOption) ([]*Address, error) {
-	return GetAddressesFromAssetInventory(ctx, scope, maps.Keys(getAddressByAssetType), opts...)
+	return GetAllAddressesFromAssetInventoryWithOptions(ctx, scope, Options{}, opts...)
+}
+
+// GetAllAddressesFromAssetInventoryWithOptions is the same as GetAllAddressesFromAssetInventory but allows for customizing the returned addresses
+func GetAllAddressesFromAssetInventoryWithOptions(ctx context.Context, scope string, options Options, opts ...option.ClientOption) ([]*Address, error) {
+	assetTypes := slices.DeleteFunc(maps.Keys(getAddressByAssetType), func(assetType string) bool {
+		return slices.Contains(optInAssetTypes, assetType)
+	})
+	if options.IncludeKubernetesGateways {
+		assetTypes = append(assetTypes, AssetTypeKubernetesGateway)
+	}
+
+	return GetAddressesFromAssetInventoryWithOptions(ctx, scope, assetTypes, options, opts...)
 }
 
 // GetAddressesFromAssetInventory queries the Cloud Asset Inventory API and returns back IP addresses from the specified asset types
 func GetAddressesFromAssetInventory(ctx context.Context, scope string, assetTypes []string, opts ...option.ClientOption) ([]*Address, error) {
-	c, err := asset.NewClient(ctx, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("error setting up client: %w", err)
-	}
-	defer c.Close() //nolint:errcheck
+	return GetAddressesFromAssetInventoryWithOptions(ctx, scope, assetTypes, Options{}, opts...)
+}
 
+// GetAddressesFromAssetInventoryWithOptions is the same as GetAddressesFromAssetInventory but allows for customizing the returned addresses
+func GetAddressesFromAssetInventoryWithOptions(ctx context.Context, scope string, assetTypes []string, options Options, opts ...option.ClientOption) ([]*Address, error) {
 	for _, val := range assetTypes {
 		if _, ok := getAddressByAssetType[val]; !ok {
 			return nil, fmt.Errorf("unsupported asset type: %s", val)
 		}
 	}
 
-	removeAddressesLater := false
+	// Some assets (i.e. NAT routers) only reference the resource holding their IP address, not the IP address itself.
+	// As a result, if we are pulling down those assets without pulling down the referenced resources explicitly, we need to add them under the hood
+	// so we can resolve the reference later
+	implicitAssetTypes := []string{}
+	for _, val := range assetTypes {
+		referencedType, ok := referencedAssetTypes[val]
+		if !ok || slices.Contains(assetTypes, referencedType) || slices.Contains(implicitAssetTypes, referencedType) {
+			continue
+		}
+		implicitAssetTypes = append(implicitAssetTypes, referencedType)
+	}
+
+	// Some assets (i.e. AlloyDB instances) only know the parent resource holding their VPC network
+	for _, val := range assetTypes {
+		parentType, ok := networkParentAssetTypes[val]
+		if !ok || slices.Contains(assetTypes, parentType) || slices.Contains(implicitAssetTypes, parentType) {
+			continue
+		}
+		implicitAssetTypes = append(implicitAssetTypes, parentType)
+	}
+
+	// Detecting privately used public ranges requires the subnets
+	if options.DetectPrivatelyUsedPublicRanges && !slices.Contains(assetTypes, AssetTypeComputeSubnetwork) {
+		implicitAssetTypes = append(implicitAssetTypes, AssetTypeComputeSubnetwork)
+	}
+	assetTypes = append(slices.Clone(assetTypes), implicitAssetTypes...)
+
+	var results []*Address
+	networks := map[string]string{}
+	networkParents := map[*Address]string{}
+
+	err := searchAllResources(ctx, scope, assetTypes, func(resource *assetpb.ResourceSearchResult) error {
+		networkGetter, hasNetwork := getNetworkByAssetType[resource.AssetType]
+		if hasNetwork {
+			networks[resource.Name] = networkGetter(resource)
+		}
+
+		addressGetter := getAddressByAssetType[resource.AssetType]
+		if addressGetter == nil {
+			// Parent resources without addresses of their own are only fetched for their network
+			if hasNetwork {
+				return nil
+			}
+			return fmt.Errorf("unexpected asset type: %s", resource.AssetType)
+		}
+
+		networkParent := ""
+		if parentGetter, ok := getNetworkParentByAssetType[resource.AssetType]; ok {
+			networkParent = parentGetter(resource)
+		}
+
+		addresses := addressGetter(resource)
+		for _, addr := range addresses {
+			setResourceMetadata(addr, resource)
+			if networkParent != "" {
+				networkParents[addr] = networkParent
+			}
+		}
+		results = append(results, addresses...)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	setParentNetworks(results, networks, networkParents)
+
+	privateRanges := slices.Clone(options.PrivateRanges)
+	if options.DetectPrivatelyUsedPublicRanges {
+		privateRanges = append(privateRanges, subnetPublicRanges(results)...)
+	}
+
+	addresses := cleanupAssets(results, implicitAssetTypes, privateRanges)
+
+	for _, addr := range addresses {
+		if addr.AddressType == AddressTypeUnresolved {
+			continue
+		}
+
+		// Addresses known to be internal (see setInternal) stay privately used even outside of the private ranges
+		classification := classifyAddressWithPrivateRanges(addr.Address, privateRanges)
+		if classification == ClassificationGlobalUnicast && addr.Classification == ClassificationPrivatelyUsedPublic {
+			classification = ClassificationPrivatelyUsedPublic
+		}
+
+		addr.IPVersion = ipVersion(addr.Address)
+		addr.Classification = classification
+		addr.AddressType = addressTypeForClassification(addr.Classification)
+
+		if len(options.GoogleRanges) > 0 {
+			annotateGoogleRange(addr, options.GoogleRanges)
+		}
+	}
+
+	if !options.IncludeRanges {
+		addresses = FilterSingleAddresses(addresses)
+	}
+
+	if !options.IncludeReserved {
+		addresses = slices.DeleteFunc(addresses, (*Address).IsReserved)
+	}
+
+	return addresses, nil
+}
 
-	if slices.Contains(assetTypes, AssetTypeComputeRouter) && !slices.Contains(assetTypes, AssetTypeComputeAddress) {
-		// NAT routers only reference the Address resource name, not the IP address itself.
-		// As a result, if we are pulling down the NAT routers without pulling down the Address resources explicitly, we need to add it under the hood
-		// so we can resolve the reference later
-		assetTypes = append(assetTypes, AssetTypeComputeAddress)
-		removeAddressesLater = true
+// searchAllResources queries the Cloud Asset Inventory API for all resources of the specified asset types and calls fn for each one
+func searchAllResources(ctx context.Context, scope string, assetTypes []string, fn func(resource *assetpb.ResourceSearchResult) error, opts ...option.ClientOption) error {
+	c, err := asset.NewClient(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("error setting up client: %w", err)
 	}
+	defer c.Close() //nolint:errcheck
 
 	req := &assetpb.SearchAllResourcesRequest{
 		Scope:      scope,
@@ -76,55 +334,108 @@ func GetAddressesFromAssetInventory(ctx context.Context, scope string, assetType
 
 	it := c.SearchAllResources(ctx, req)
 
-	var results []*Address
-
 	for {
 		resource, err := it.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("error searching for resources: %w", err)
+			return fmt.Errorf("error searching for resources: %w", err)
 		}
 
-		addressGetter := getAddressByAssetType[resource.AssetType]
-		if addressGetter == nil {
-			return nil, fmt.Errorf("unexpected asset type: %s", resource.AssetType)
+		if err := fn(resource); err != nil {
+			return err
 		}
+	}
 
-		addresses := addressGetter(resource)
-		results = append(results, addresses...)
+	return nil
+}
+
+// setResourceMetadata copies the project, location, labels and other metadata of the asset to the address
+func setResourceMetadata(addr *Address, resource *assetpb.ResourceSearchResult) {
+	addr.Project = resource.Project
+	addr.Location = resource.Location
+	addr.DisplayName = resource.DisplayName
+	addr.State = resource.State
+	addr.ParentFullResourceName = resource.ParentFullResourceName
+
+	if len(resource.Labels) > 0 {
+		addr.Labels = resource.Labels
+	}
+
+	if resource.CreateTime != nil {
+		addr.CreateTime = resource.CreateTime.AsTime().Format(time.RFC3339)
+	}
+}
+
+// setParentNetworks sets the VPC network of private addresses that don't know it from the parent resource they are
+// allocated from (i.e. their subnetwork, AlloyDB cluster, GKE cluster or Cloud Router), given the networks of the parent
+// resources by full resource name and the parents that aren't recorded on the addresses themselves
+func setParentNetworks(addresses []*Address, networks map[string]string, networkParents map[*Address]string) {
+	for _, addr := range addresses {
+		if addr.Network != "" || addr.AddressType != AddressTypePrivate {
+			continue
+		}
+
+		if network, ok := networks[networkParents[addr]]; ok {
+			addr.Network = network
+		} else if network, ok := networks[addr.Subnetwork]; ok {
+			addr.Network = network
+		} else if network, ok := networks[addr.ParentFullResourceName]; ok {
+			addr.Network = network
+		} else if network, ok := networks[addr.KubernetesCluster]; ok && !isKubernetesClusterIP(addr) {
+			addr.Network = network
+		}
 	}
+}
 
-	return cleanupAssets(results, removeAddressesLater), nil
+// isKubernetesClusterIP returns true for the cluster IP of a Kubernetes Service, which is the only Service address
+// classified up front (see getAddressForKubernetesService). Cluster IPs are virtual IPs that are only reachable inside
+// their cluster and GKE reuses the same service range across clusters, so they don't belong to the VPC network.
+func isKubernetesClusterIP(addr *Address) bool {
+	return addr.ResourceType == AssetTypeKubernetesService && addr.Classification != ""
 }
 
-// cleanupAssets removes duplicate addresses from the list of assets
-func cleanupAssets(assets []*Address, removeAddresses bool) []*Address {
-	// Resolve references to the IP of their associated Address resource
-	computeAddressMap := map[string]*Address{}
-	routerReferences := []*Address{}
+// cleanupAssets resolves references and removes duplicate addresses from the list of assets
+func cleanupAssets(assets []*Address, removeAssetTypes []string, privateRanges []netip.Prefix) []*Address {
+	// Resolve references to the IP of their associated resource
+	resourceMap := map[string]*Address{}
+	references := []*Address{}
 
 	for _, addr := range assets {
-		if addr.ResourceType == AssetTypeComputeAddress {
-			computeAddressMap[addr.ResourceName] = addr
+		if addr.AddressType == AddressTypeReference {
+			references = append(references, addr)
+			continue
+		}
+
+		if addr.IsRange() {
+			continue
 		}
 
-		if addr.AddressType == "reference" && addr.ResourceType == AssetTypeComputeRouter {
-			routerReferences = append(routerReferences, addr)
+		if _, ok := resourceMap[addr.ResourceName]; !ok {
+			resourceMap[addr.ResourceName] = addr
 		}
 	}
 
-	for _, router := range routerReferences {
-		if addr, ok := computeAddressMap[router.Address]; ok {
-			router.Address = addr.Address
-			router.AddressType = addr.AddressType
+	// References to resources that weren't found are kept without an address so they can be reported
+	unresolved := []*Address{}
+	for _, ref := range references {
+		if addr, ok := resourceMap[ref.Address]; ok {
+			ref.Address = addr.Address
+			ref.AddressType = addr.AddressType
+			ref.Reference = ""
+			continue
 		}
+
+		ref.Address = ""
+		ref.AddressType = AddressTypeUnresolved
+		unresolved = append(unresolved, ref)
 	}
 
-	// Remove reference assets from the list of assets since they should have been resolved above
+	// Remove reference assets from the list of assets since they have been resolved above, unresolved references
+	// don't have an IP to be deduplicated with so they are added back at the end
 	assets = slices.DeleteFunc(assets, func(a *Address) bool {
-		return a.AddressType == "reference"
+		return a.AddressType == AddressTypeReference || a.AddressType == AddressTypeUnresolved
 	})
 
 	// Iterate over the list of assets and remove duplicate Address entries where the IP matches another
@@ -132,18 +443,39 @@ func cleanupAssets(assets []*Address, removeAddresses bool) []*Address {
 	seen := map[string]*Address{}
 
 	for _, asset := range assets {
-		if match, ok := seen[asset.Address]; !ok {
-			seen[asset.Address] = asset
+		key := asset.Address
+
+		// Private IPs can be reused in different VPC networks so they are only deduplicated within the same network.
+		// Assets whose network isn't known can't be matched to any network so they are kept as separate entries.
+		if !asset.IsRange() && isPrivateAddress(asset, privateRanges) {
+			key = asset.Network + "|" + asset.Address
+			if asset.Network == "" {
+				key = asset.ResourceName + "|" + asset.Address
+			}
+		}
+
+		// Ranges are allocations that belong to a specific resource (i.e. a GKE cluster using a subnet's secondary range)
+		// so they are only deduplicated within the same resource
+		if asset.IsRange() {
+			key = asset.ResourceName + "|" + asset.Prefix
+		}
+
+		if match, ok := seen[key]; !ok {
+			seen[key] = asset
 		} else {
-			if match.ResourceType == AssetTypeComputeAddress {
-				seen[asset.Address] = asset
+			// Keep track of the other resources sharing the IP on the more-specific asset
+			winner, loser := match, asset
+			if assetTypePrecedence(asset.ResourceType) > assetTypePrecedence(match.ResourceType) {
+				winner, loser = asset, match
+				seen[key] = asset
 			}
+			addRelatedResources(winner, loser)
 		}
 	}
 
 	addresses := []*Address{}
-	for _, asset := range seen {
-		if removeAddresses && asset.ResourceType == AssetTypeComputeAddress {
+	for _, asset := range append(maps.Values(seen), unresolved...) {
+		if slices.Contains(removeAssetTypes, asset.ResourceType) {
 			continue
 		}
 		addresses = append(addresses, asset)
@@ -152,11 +484,71 @@ func cleanupAssets(assets []*Address, removeAddresses bool) []*Address {
 	return addresses
 }
 
+// addRelatedResources records the resource of a duplicate address (and the resources related to it) as related resources
+// of the address that replaces it
+func addRelatedResources(addr *Address, duplicate *Address) {
+	related := append([]RelatedResource{relatedResource(duplicate)}, duplicate.Related...)
+
+	for _, r := range related {
+		if r.ResourceName == addr.ResourceName || slices.Contains(addr.Related, r) {
+			continue
+		}
+		addr.Related = append(addr.Related, r)
+	}
+}
+
+// relatedResource describes how the resource of an address relates to an address of another resource with the same IP
+func relatedResource(addr *Address) RelatedResource {
+	relationship := RelationshipUsedBy
+	if addr.ResourceType == AssetTypeComputeAddress || addr.ResourceType == AssetTypeComputeGlobalAddress {
+		relationship = RelationshipReservedBy
+	}
+
+	return RelatedResource{
+		ResourceName: addr.ResourceName,
+		ResourceType: addr.ResourceType,
+		Relationship: relationship,
+	}
+}
+
+// assetTypePrecedence ranks how specific an asset type is when several assets share the same IP.
+// Static addresses are used by forwarding rules, which are in turn typically owned by something more specific
+// (i.e. a VPN gateway), so those rank lowest. The BGP addresses of Cloud Routers are managed by the Interconnect
+// attachments using them, so routers rank with forwarding rules.
+func assetTypePrecedence(assetType string) int {
+	switch assetType {
+	case AssetTypeComputeAddress, AssetTypeComputeGlobalAddress:
+		return 0
+	case AssetTypeComputeForwardingRule, AssetTypeComputeRouter:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ipType rolls the classification of an IPv4 or IPv6 address up to public or private
 func ipType(ip string) string {
-	ipAddr := net.ParseIP(ip)
-	if ipAddr.IsPrivate() {
-		return AddressTypePrivate
-	} else {
-		return AddressTypePublic
+	return addressTypeForClassification(ClassifyAddress(ip))
+}
+
+// isPrivateAddress returns true if the address is private, taking privately used public ranges and addresses known to be
+// internal into account
+func isPrivateAddress(addr *Address, privateRanges []netip.Prefix) bool {
+	if addr.Classification == ClassificationPrivatelyUsedPublic {
+		return true
+	}
+	return addressTypeForClassification(classifyAddressWithPrivateRanges(addr.Address, privateRanges)) == AddressTypePrivate
+}
+
+// ipVersion returns the IP version of an address or an empty string if it isn't a valid IP address
+func ipVersion(ip string) string {
+	ipAddr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+
+	if ipAddr.Unmap().Is4() {
+		return IPVersion4
 	}
+	return IPVersion6
 }
@@ -0,0 +1,138 @@
+package gcp
+
+import (
+	"net/netip"
+)
+
+const (
+	// ClassificationRFC1918 refers to an IPv4 private address (10.0.0.0/8, 172.16.0.0/12 and 192.168.0.0/16)
+	ClassificationRFC1918 = "rfc1918"
+
+	// ClassificationShared refers to an IPv4 shared address space (100.64.0.0/10) address, typically used for carrier-grade NAT
+	ClassificationShared = "shared"
+
+	// ClassificationLinkLocal refers to an IPv4 (169.254.0.0/16) or IPv6 (fe80::/10) link-local address, which is used
+	// by Cloud Interconnect and Cloud VPN BGP sessions
+	ClassificationLinkLocal = "link-local"
+
+	// ClassificationLoopback refers to an IPv4 (127.0.0.0/8) or IPv6 (::1) loopback address
+	ClassificationLoopback = "loopback"
+
+	// ClassificationULA refers to an IPv6 unique local address (fc00::/7)
+	ClassificationULA = "ula"
+
+	// ClassificationGlobalUnicast refers to an internet-routable address
+	ClassificationGlobalUnicast = "global-unicast"
+
+	// ClassificationPrivatelyUsedPublic refers to a public address that is only used privately, such as the range
+	// Cloud DNS forwarding and Cloud Load Balancing health checks (35.199.192.0/19) use inside of VPC networks
+	ClassificationPrivatelyUsedPublic = "privately-used-public"
+
+	// ClassificationReserved refers to any other special-purpose address that isn't internet-routable
+	// (i.e. documentation, benchmarking, multicast or unspecified addresses)
+	ClassificationReserved = "reserved"
+)
+
+var classificationPrefixes = []struct {
+	prefix         netip.Prefix
+	classification string
+}{
+	{netip.MustParsePrefix("10.0.0.0/8"), ClassificationRFC1918},
+	{netip.MustParsePrefix("172.16.0.0/12"), ClassificationRFC1918},
+	{netip.MustParsePrefix("192.168.0.0/16"), ClassificationRFC1918},
+	{netip.MustParsePrefix("100.64.0.0/10"), ClassificationShared},
+	{netip.MustParsePrefix("169.254.0.0/16"), ClassificationLinkLocal},
+	{netip.MustParsePrefix("fe80::/10"), ClassificationLinkLocal},
+	{netip.MustParsePrefix("127.0.0.0/8"), ClassificationLoopback},
+	{netip.MustParsePrefix("::1/128"), ClassificationLoopback},
+	{netip.MustParsePrefix("fc00::/7"), ClassificationULA},
+	{netip.MustParsePrefix("35.199.192.0/19"), ClassificationPrivatelyUsedPublic},
+	{netip.MustParsePrefix("0.0.0.0/8"), ClassificationReserved},
+	{netip.MustParsePrefix("192.0.0.0/24"), ClassificationReserved},
+	{netip.MustParsePrefix("192.0.2.0/24"), ClassificationReserved},
+	{netip.MustParsePrefix("198.18.0.0/15"), ClassificationReserved},
+	{netip.MustParsePrefix("198.51.100.0/24"), ClassificationReserved},
+	{netip.MustParsePrefix("203.0.113.0/24"), ClassificationReserved},
+	{netip.MustParsePrefix("224.0.0.0/4"), ClassificationReserved},
+	{netip.MustParsePrefix("240.0.0.0/4"), ClassificationReserved},
+	{netip.MustParsePrefix("::/128"), ClassificationReserved},
+	{netip.MustParsePrefix("2001:db8::/32"), ClassificationReserved},
+	{netip.MustParsePrefix("ff00::/8"), ClassificationReserved},
+}
+
+// ClassifyAddress returns the fine-grained classification of an IPv4 or IPv6 address (one of the Classification constants)
+// or an empty string if it isn't a valid IP address
+func ClassifyAddress(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	for _, p := range classificationPrefixes {
+		if p.prefix.Contains(addr) {
+			return p.classification
+		}
+	}
+
+	return ClassificationGlobalUnicast
+}
+
+// classifyAddressWithPrivateRanges is the same as ClassifyAddress but classifies global unicast addresses within any of the
+// private ranges as ClassificationPrivatelyUsedPublic
+func classifyAddressWithPrivateRanges(ip string, privateRanges []netip.Prefix) string {
+	classification := ClassifyAddress(ip)
+	if classification != ClassificationGlobalUnicast {
+		return classification
+	}
+
+	addr, _ := netip.ParseAddr(ip)
+	for _, prefix := range privateRanges {
+		if prefix.Contains(addr.Unmap()) {
+			return ClassificationPrivatelyUsedPublic
+		}
+	}
+
+	return classification
+}
+
+// setInternal classifies an address (or range) that is only reachable from inside a VPC network or cluster as private,
+// with public addresses classified as ClassificationPrivatelyUsedPublic (i.e. GKE's default service range 34.118.224.0/20)
+func setInternal(addr *Address) {
+	addr.Classification = ClassifyAddress(addr.Address)
+	if addr.Classification == ClassificationGlobalUnicast {
+		addr.Classification = ClassificationPrivatelyUsedPublic
+	}
+	addr.AddressType = addressTypeForClassification(addr.Classification)
+}
+
+// subnetPublicRanges returns the primary and secondary IPv4 subnet ranges that use public addresses. Subnet IPv6 ranges
+// are excluded since external IPv6 ranges are internet-routable.
+func subnetPublicRanges(addresses []*Address) []netip.Prefix {
+	ranges := []netip.Prefix{}
+
+	for _, addr := range addresses {
+		if addr.ResourceType != AssetTypeComputeSubnetwork || !addr.IsRange() {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(addr.Prefix)
+		if err != nil || !prefix.Addr().Is4() || ClassifyAddress(addr.Address) != ClassificationGlobalUnicast {
+			continue
+		}
+		ranges = append(ranges, prefix)
+	}
+
+	return ranges
+}
+
+// addressTypeForClassification rolls a classification up to AddressTypePublic or AddressTypePrivate. Only global unicast
+// addresses (and addresses that couldn't be classified) are public.
+func addressTypeForClassification(classification string) string {
+	switch classification {
+	case ClassificationGlobalUnicast, "":
+		return AddressTypePublic
+	default:
+		return AddressTypePrivate
+	}
+}
@@ -0,0 +1,34 @@
+package gcp_test
+
+import (
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyAddress(t *testing.T) {
+	testcases := map[string]string{
+		"10.0.3.2":             gcp.ClassificationRFC1918,
+		"172.16.0.2":           gcp.ClassificationRFC1918,
+		"192.168.1.1":          gcp.ClassificationRFC1918,
+		"100.64.12.1":          gcp.ClassificationShared,
+		"169.254.10.1":         gcp.ClassificationLinkLocal,
+		"fe80::1":              gcp.ClassificationLinkLocal,
+		"127.0.0.1":            gcp.ClassificationLoopback,
+		"::1":                  gcp.ClassificationLoopback,
+		"fd20:9c1:4f3a::1":     gcp.ClassificationULA,
+		"35.199.192.10":        gcp.ClassificationPrivatelyUsedPublic,
+		"34.83.128.26":         gcp.ClassificationGlobalUnicast,
+		"2600:1900:4040:2b1::": gcp.ClassificationGlobalUnicast,
+		"::ffff:10.0.3.2":      gcp.ClassificationRFC1918,
+		"203.0.113.5":          gcp.ClassificationReserved,
+		"224.0.0.251":          gcp.ClassificationReserved,
+		"2001:db8::1":          gcp.ClassificationReserved,
+		"not-an-ip":            "",
+	}
+
+	for ip, expected := range testcases {
+		require.Equal(t, expected, gcp.ClassifyAddress(ip), ip)
+	}
+}
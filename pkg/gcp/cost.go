@@ -0,0 +1,192 @@
+package gcp
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// CostCategoryVM refers to an external IPv4 address attached to a Compute Engine instance
+	CostCategoryVM = "vm"
+
+	// CostCategoryIdleStatic refers to a static external IPv4 address that is reserved but not attached to any resource
+	CostCategoryIdleStatic = "idle-static"
+
+	// CostCategoryForwardingRule refers to an external IPv4 address used by a forwarding rule, including the load balancers
+	// created for Kubernetes services, ingresses and gateways
+	CostCategoryForwardingRule = "forwarding-rule"
+
+	// CostCategoryNAT refers to an external IPv4 address used by Cloud NAT
+	CostCategoryNAT = "nat"
+
+	// CostCategoryOther refers to an external IPv4 address used by any other resource (i.e. Cloud SQL or Cloud VPN)
+	CostCategoryOther = "other"
+)
+
+// costCategories are the cost categories a pricing table can set a price for
+var costCategories = []string{
+	CostCategoryVM,
+	CostCategoryIdleStatic,
+	CostCategoryForwardingRule,
+	CostCategoryNAT,
+	CostCategoryOther,
+}
+
+// hoursPerMonth is the number of hours Google Cloud bills for in a month
+const hoursPerMonth = 730
+
+// Pricing is a table of hourly prices for external IPv4 addresses in each cost category
+type Pricing struct {
+	Currency string `yaml:"currency"`
+
+	// HourlyPrices maps a cost category (one of the CostCategory constants) to the hourly price of an address. Categories
+	// without a price are treated as free.
+	HourlyPrices map[string]float64 `yaml:"hourly_prices"`
+}
+
+// AddressCost is the estimated cost of the external IPv4 addresses in a cost category in a project
+type AddressCost struct {
+	Project     string  `json:"project"`
+	Category    string  `json:"category"`
+	Count       int     `json:"count"`
+	HourlyPrice float64 `json:"hourly_price"`
+	MonthlyCost float64 `json:"monthly_cost"`
+	Currency    string  `json:"currency,omitempty"`
+}
+
+// LoadPricing loads a pricing table from a YAML or JSON file on disk
+func LoadPricing(path string) (*Pricing, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening pricing file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	return ParsePricing(f)
+}
+
+// ParsePricing parses a pricing table in YAML or JSON (which is valid YAML), i.e.
+//
+//	currency: USD
+//	hourly_prices:
+//	  vm: 0.005
+//	  idle-static: 0.01
+func ParsePricing(r io.Reader) (*Pricing, error) {
+	pricing := &Pricing{}
+	if err := yaml.NewDecoder(r).Decode(pricing); err != nil {
+		return nil, fmt.Errorf("error parsing pricing: %w", err)
+	}
+
+	for category, price := range pricing.HourlyPrices {
+		if !slices.Contains(costCategories, category) {
+			return nil, fmt.Errorf("error parsing pricing: unknown cost category %q", category)
+		}
+		if price < 0 {
+			return nil, fmt.Errorf("error parsing pricing: negative price for cost category %q", category)
+		}
+	}
+
+	return pricing, nil
+}
+
+// unbilledAssetTypes are the asset types whose public IPv4 addresses aren't billed to the project: subnet gateways are
+// only reachable inside the VPC network and GKE control plane endpoints are owned by Google
+var unbilledAssetTypes = []string{
+	AssetTypeComputeSubnetwork,
+	AssetTypeContainerCluster,
+}
+
+// CostCategory returns the cost category (one of the CostCategory constants) of an external IPv4 address based on the
+// resources using it, or an empty string if the address isn't billed (i.e. private, IPv6, a range or a GKE control plane
+// endpoint). Internal addresses such as INTERNAL static addresses, subnet gateways and Kubernetes cluster IPs are
+// private even when they use public IPs, so they aren't billed either.
+func CostCategory(addr *Address) string {
+	if addr.AddressType != AddressTypePublic || addr.IPVersion != IPVersion4 || addr.IsRange() {
+		return ""
+	}
+
+	if slices.Contains(unbilledAssetTypes, addr.ResourceType) {
+		return ""
+	}
+
+	if addr.IsReserved() {
+		return CostCategoryIdleStatic
+	}
+
+	// Static addresses are deduplicated with the resources using them, so those are only known from the related resources
+	resourceTypes := []string{addr.ResourceType}
+	for _, r := range addr.Related {
+		if r.Relationship == RelationshipUsedBy {
+			resourceTypes = append(resourceTypes, r.ResourceType)
+		}
+	}
+
+	switch {
+	case slices.Contains(resourceTypes, AssetTypeComputeInstance):
+		return CostCategoryVM
+	case slices.Contains(resourceTypes, AssetTypeComputeRouter):
+		return CostCategoryNAT
+	case slices.ContainsFunc(resourceTypes, isLoadBalancerAssetType):
+		return CostCategoryForwardingRule
+	default:
+		return CostCategoryOther
+	}
+}
+
+// isLoadBalancerAssetType returns true for forwarding rules and the Kubernetes resources that are exposed with them
+func isLoadBalancerAssetType(assetType string) bool {
+	switch assetType {
+	case AssetTypeComputeForwardingRule, AssetTypeKubernetesService, AssetTypeKubernetesIngress, AssetTypeKubernetesGateway:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetAddressCosts groups the external IPv4 addresses by project and cost category and estimates their monthly cost with
+// the given pricing. Reserved addresses must be included (see Options.IncludeReserved) for idle static addresses to be
+// reported. Results are sorted by project, then by category.
+func GetAddressCosts(addresses []*Address, pricing *Pricing) []*AddressCost {
+	costs := map[[2]string]*AddressCost{}
+
+	for _, addr := range addresses {
+		category := CostCategory(addr)
+		if category == "" {
+			continue
+		}
+
+		key := [2]string{addr.Project, category}
+		cost, ok := costs[key]
+		if !ok {
+			cost = &AddressCost{
+				Project:     addr.Project,
+				Category:    category,
+				HourlyPrice: pricing.HourlyPrices[category],
+				Currency:    pricing.Currency,
+			}
+			costs[key] = cost
+		}
+
+		cost.Count++
+		cost.MonthlyCost = float64(cost.Count) * cost.HourlyPrice * hoursPerMonth
+	}
+
+	results := []*AddressCost{}
+	for _, cost := range costs {
+		results = append(results, cost)
+	}
+
+	slices.SortFunc(results, func(a, b *AddressCost) int {
+		return cmp.Or(
+			cmp.Compare(a.Project, b.Project),
+			cmp.Compare(slices.Index(costCategories, a.Category), slices.Index(costCategories, b.Category)),
+		)
+	})
+
+	return results
+}
@@ -0,0 +1,127 @@
+package gcp_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestParsePricing(t *testing.T) {
+	testcases := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "yaml",
+			input: "currency: USD\nhourly_prices:\n  vm: 0.005\n  idle-static: 0.01\n",
+		},
+		{
+			name:  "json",
+			input: `{"currency": "USD", "hourly_prices": {"vm": 0.005, "idle-static": 0.01}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			pricing, err := gcp.ParsePricing(strings.NewReader(tc.input))
+			require.NoError(t, err)
+
+			require.Equal(t, &gcp.Pricing{
+				Currency:     "USD",
+				HourlyPrices: map[string]float64{gcp.CostCategoryVM: 0.005, gcp.CostCategoryIdleStatic: 0.01},
+			}, pricing)
+		})
+	}
+}
+
+func TestParsePricingUnknownCategory(t *testing.T) {
+	_, err := gcp.ParsePricing(strings.NewReader("hourly_prices:\n  static: 0.01\n"))
+	require.ErrorContains(t, err, `unknown cost category "static"`)
+}
+
+func TestGetAddressCosts(t *testing.T) {
+	server, err := setupTestServer()
+	if err != nil {
+		t.Fatalf("error setting up test server: %s", err)
+	}
+	defer server.Close() //nolint:errcheck
+
+	addresses, err := gcp.GetAllAddressesFromAssetInventoryWithOptions(
+		context.Background(),
+		scope,
+		gcp.Options{IncludeReserved: true, IncludeKubernetesGateways: true},
+
+		// These are necessary to get the Google Cloud SDK to use the fake grpc server
+		option.WithEndpoint(server.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("error getting addresses from asset inventory: %s", err)
+	}
+
+	categories := map[string]string{}
+	for _, addr := range addresses {
+		if category := gcp.CostCategory(addr); category != "" {
+			categories[addr.Address] = category
+		}
+	}
+
+	// Static addresses are categorized by the resources using them
+	require.Equal(t, gcp.CostCategoryVM, categories["34.83.128.26"])
+	require.Equal(t, gcp.CostCategoryNAT, categories["34.19.80.22"])
+	require.Equal(t, gcp.CostCategoryForwardingRule, categories["34.54.244.120"])
+	require.Equal(t, gcp.CostCategoryForwardingRule, categories["34.117.88.201"])
+	require.Equal(t, gcp.CostCategoryIdleStatic, categories["34.19.68.170"])
+	require.Equal(t, gcp.CostCategoryOther, categories["35.247.31.30"])
+
+	// IPv6 and private addresses aren't billed
+	require.NotContains(t, categories, "2600:1900:4040:2b1::")
+	require.NotContains(t, categories, "10.0.3.3")
+
+	// Internal addresses using public IPs (subnet gateway, INTERNAL static address, Kubernetes cluster IP) and the GKE
+	// control plane endpoint aren't billed
+	require.NotContains(t, categories, "11.0.0.1")
+	require.NotContains(t, categories, "11.0.0.10")
+	require.NotContains(t, categories, "34.118.230.12")
+	require.NotContains(t, categories, "34.105.114.31")
+
+	// Managed service endpoints allocated from PUPI space are internal too
+	require.NotContains(t, categories, "12.0.0.3")
+
+	costs := gcp.GetAddressCosts(addresses, &gcp.Pricing{
+		Currency:     "USD",
+		HourlyPrices: map[string]float64{gcp.CostCategoryVM: 0.005, gcp.CostCategoryIdleStatic: 0.01},
+	})
+
+	type categoryCost struct {
+		category string
+		count    int
+	}
+
+	actual := []categoryCost{}
+	for _, cost := range costs {
+		require.Equal(t, "projects/828107101350", cost.Project)
+		actual = append(actual, categoryCost{cost.Category, cost.Count})
+	}
+
+	require.Equal(t, []categoryCost{
+		{gcp.CostCategoryVM, 3},
+		{gcp.CostCategoryIdleStatic, 1},
+		{gcp.CostCategoryForwardingRule, 6},
+		{gcp.CostCategoryNAT, 1},
+		{gcp.CostCategoryOther, 5},
+	}, actual)
+
+	require.InDelta(t, 10.95, costs[0].MonthlyCost, 0.001)
+	require.InDelta(t, 7.3, costs[1].MonthlyCost, 0.001)
+
+	// Categories without a price are free
+	require.Zero(t, costs[4].MonthlyCost)
+}
@@ -27,3 +27,104 @@ func FilterPrivateAddresses(addrs []*Address) []*Address {
 
 	return filtered
 }
+
+// FilterSingleAddresses filters the given slice of addresses to only include single addresses (excluding ranges)
+func FilterSingleAddresses(addrs []*Address) []*Address {
+	filtered := []*Address{}
+
+	for _, a := range addrs {
+		if a.IsRange() {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	return filtered
+}
+
+// FilterRanges filters the given slice of addresses to only include allocated ranges
+func FilterRanges(addrs []*Address) []*Address {
+	filtered := []*Address{}
+
+	for _, a := range addrs {
+		if !a.IsRange() {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	return filtered
+}
+
+// FilterIPv4Addresses filters the given slice of addresses to only include IPv4 addresses and ranges
+func FilterIPv4Addresses(addrs []*Address) []*Address {
+	filtered := []*Address{}
+
+	for _, a := range addrs {
+		if a.IPVersion != IPVersion4 {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	return filtered
+}
+
+// FilterIPv6Addresses filters the given slice of addresses to only include IPv6 addresses and ranges
+func FilterIPv6Addresses(addrs []*Address) []*Address {
+	filtered := []*Address{}
+
+	for _, a := range addrs {
+		if a.IPVersion != IPVersion6 {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	return filtered
+}
+
+// FilterBYOIPAddresses filters the given slice of addresses to only include public addresses outside of Google's published
+// IP ranges (see Options.GoogleRanges)
+func FilterBYOIPAddresses(addrs []*Address) []*Address {
+	filtered := []*Address{}
+
+	for _, a := range addrs {
+		if !a.BYOIP {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	return filtered
+}
+
+// FilterReservedAddresses filters the given slice of addresses to only include static addresses that are reserved but not
+// attached to any resource (see Options.IncludeReserved)
+func FilterReservedAddresses(addrs []*Address) []*Address {
+	filtered := []*Address{}
+
+	for _, a := range addrs {
+		if !a.IsReserved() {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	return filtered
+}
+
+// FilterUnresolvedReferences filters the given slice of addresses to only include references to resources that weren't
+// found (see AddressTypeUnresolved)
+func FilterUnresolvedReferences(addrs []*Address) []*Address {
+	filtered := []*Address{}
+
+	for _, a := range addrs {
+		if a.AddressType != AddressTypeUnresolved {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	return filtered
+}
@@ -42,3 +42,121 @@ func TestFilterPrivateAddresses(t *testing.T) {
 	require.Len(t, filtered, 1)
 	require.EqualValues(t, testAddresses[0:1], filtered)
 }
+
+func TestFilterSingleAddresses(t *testing.T) {
+	testAddresses := []*gcp.Address{
+		{
+			Address:     "10.0.1.2",
+			AddressType: gcp.AddressTypePrivate,
+		},
+		{
+			Address:     "10.84.0.0",
+			AddressType: gcp.AddressTypePrivate,
+			Prefix:      "10.84.0.0/14",
+		},
+	}
+
+	filtered := gcp.FilterSingleAddresses(testAddresses)
+
+	require.Len(t, filtered, 1)
+	require.EqualValues(t, testAddresses[0:1], filtered)
+}
+
+func TestFilterRanges(t *testing.T) {
+	testAddresses := []*gcp.Address{
+		{
+			Address:     "10.0.1.2",
+			AddressType: gcp.AddressTypePrivate,
+		},
+		{
+			Address:     "10.84.0.0",
+			AddressType: gcp.AddressTypePrivate,
+			Prefix:      "10.84.0.0/14",
+		},
+	}
+
+	filtered := gcp.FilterRanges(testAddresses)
+
+	require.Len(t, filtered, 1)
+	require.EqualValues(t, testAddresses[1:2], filtered)
+}
+
+func TestFilterIPv4Addresses(t *testing.T) {
+	testAddresses := []*gcp.Address{
+		{
+			Address:   "10.0.1.2",
+			IPVersion: gcp.IPVersion4,
+		},
+		{
+			Address:   "2600:1900:4040:2b1::",
+			IPVersion: gcp.IPVersion6,
+		},
+	}
+
+	filtered := gcp.FilterIPv4Addresses(testAddresses)
+
+	require.Len(t, filtered, 1)
+	require.EqualValues(t, testAddresses[0:1], filtered)
+}
+
+func TestFilterIPv6Addresses(t *testing.T) {
+	testAddresses := []*gcp.Address{
+		{
+			Address:   "10.0.1.2",
+			IPVersion: gcp.IPVersion4,
+		},
+		{
+			Address:   "2600:1900:4040:2b1::",
+			IPVersion: gcp.IPVersion6,
+		},
+	}
+
+	filtered := gcp.FilterIPv6Addresses(testAddresses)
+
+	require.Len(t, filtered, 1)
+	require.EqualValues(t, testAddresses[1:2], filtered)
+}
+
+func TestFilterReservedAddresses(t *testing.T) {
+	testAddresses := []*gcp.Address{
+		{
+			Address:      "34.19.80.22",
+			ResourceType: gcp.AssetTypeComputeAddress,
+			State:        "IN_USE",
+		},
+		{
+			Address:      "34.19.68.170",
+			ResourceType: gcp.AssetTypeComputeAddress,
+			State:        "RESERVED",
+		},
+		{
+			Address:      "10.252.0.0",
+			Prefix:       "10.252.0.0/16",
+			ResourceType: gcp.AssetTypeComputeGlobalAddress,
+			State:        "RESERVED",
+		},
+	}
+
+	filtered := gcp.FilterReservedAddresses(testAddresses)
+
+	require.Len(t, filtered, 1)
+	require.EqualValues(t, testAddresses[1:2], filtered)
+}
+
+func TestFilterUnresolvedReferences(t *testing.T) {
+	testAddresses := []*gcp.Address{
+		{
+			Address:     "34.19.80.22",
+			AddressType: gcp.AddressTypePublic,
+		},
+		{
+			AddressType: gcp.AddressTypeUnresolved,
+			Reference:   "//compute.googleapis.com/projects/other-project/regions/us-west1/addresses/nat",
+		},
+	}
+
+	filtered := gcp.FilterUnresolvedReferences(testAddresses)
+
+	require.Len(t, filtered, 1)
+	require.EqualValues(t, testAddresses[1:2], filtered)
+}
@@ -0,0 +1,105 @@
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+)
+
+// GoogleRange is a range from one of Google's published IP range files
+// (https://www.gstatic.com/ipranges/cloud.json or https://www.gstatic.com/ipranges/goog.json)
+type GoogleRange struct {
+	Prefix netip.Prefix
+
+	// Service and Scope (i.e. "Google Cloud" and "us-west1") are only published in cloud.json
+	Service string
+	Scope   string
+}
+
+type googleRangeFile struct {
+	Prefixes []struct {
+		IPv4Prefix string `json:"ipv4Prefix"`
+		IPv6Prefix string `json:"ipv6Prefix"`
+		Service    string `json:"service"`
+		Scope      string `json:"scope"`
+	} `json:"prefixes"`
+}
+
+// LoadGoogleRanges loads the ranges from one of Google's published IP range files on disk
+func LoadGoogleRanges(path string) ([]GoogleRange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening google ip ranges file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	return ParseGoogleRanges(f)
+}
+
+// ParseGoogleRanges parses the ranges from one of Google's published IP range files
+func ParseGoogleRanges(r io.Reader) ([]GoogleRange, error) {
+	file := googleRangeFile{}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("error parsing google ip ranges: %w", err)
+	}
+
+	ranges := []GoogleRange{}
+
+	for _, p := range file.Prefixes {
+		cidr := p.IPv4Prefix
+		if cidr == "" {
+			cidr = p.IPv6Prefix
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing google ip range %q: %w", cidr, err)
+		}
+
+		ranges = append(ranges, GoogleRange{
+			Prefix:  prefix.Masked(),
+			Service: p.Service,
+			Scope:   p.Scope,
+		})
+	}
+
+	return ranges, nil
+}
+
+// annotateGoogleRange flags whether a public address (or range) is inside Google-owned space based on the most specific
+// Google range containing it. Public addresses outside of Google's ranges are flagged as BYOIP. Internal addresses using
+// public IPs are classified as privately used public IPs (and therefore private) so they're never flagged.
+func annotateGoogleRange(addr *Address, googleRanges []GoogleRange) {
+	if addr.AddressType != AddressTypePublic {
+		return
+	}
+
+	prefix, err := addressPrefix(addr)
+	if err != nil {
+		return
+	}
+	prefix = prefix.Masked()
+
+	var match *GoogleRange
+	for i, r := range googleRanges {
+		if r.Prefix.Bits() > prefix.Bits() || !r.Prefix.Contains(prefix.Addr()) {
+			continue
+		}
+
+		// Prefer the most specific range, and the cloud.json range over the same goog.json range since it has a scope
+		if match == nil || r.Prefix.Bits() > match.Prefix.Bits() || (r.Prefix.Bits() == match.Prefix.Bits() && match.Service == "") {
+			match = &googleRanges[i]
+		}
+	}
+
+	if match == nil {
+		addr.BYOIP = true
+		return
+	}
+
+	addr.GoogleOwned = true
+	addr.GoogleService = match.Service
+	addr.GoogleScope = match.Scope
+}
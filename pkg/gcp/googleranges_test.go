@@ -0,0 +1,77 @@
+package gcp_test
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestLoadGoogleRanges(t *testing.T) {
+	ranges, err := gcp.LoadGoogleRanges("test-data/cloud.json")
+	require.NoError(t, err)
+
+	require.Len(t, ranges, 4)
+	require.Equal(t, gcp.GoogleRange{Prefix: netip.MustParsePrefix("34.19.0.0/17"), Service: "Google Cloud", Scope: "us-west1"}, ranges[0])
+	require.Equal(t, gcp.GoogleRange{Prefix: netip.MustParsePrefix("2600:1900:4040::/44"), Service: "Google Cloud", Scope: "us-west1"}, ranges[3])
+}
+
+func TestGetAssetsWithGoogleRanges(t *testing.T) {
+	cloudRanges, err := gcp.LoadGoogleRanges("test-data/cloud.json")
+	require.NoError(t, err)
+
+	googRanges, err := gcp.LoadGoogleRanges("test-data/goog.json")
+	require.NoError(t, err)
+
+	server, err := setupTestServer()
+	if err != nil {
+		t.Fatalf("error setting up test server: %s", err)
+	}
+	defer server.Close() //nolint:errcheck
+
+	addresses, err := gcp.GetAddressesFromAssetInventoryWithOptions(
+		context.Background(),
+		scope,
+		[]string{"compute.googleapis.com/Address", "compute.googleapis.com/GlobalAddress"},
+		gcp.Options{GoogleRanges: append(googRanges, cloudRanges...)},
+
+		// These are necessary to get the Google Cloud SDK to use the fake grpc server
+		option.WithEndpoint(server.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("error getting addresses from asset inventory: %s", err)
+	}
+
+	type googleRange struct {
+		owned   bool
+		service string
+		scope   string
+		byoip   bool
+	}
+
+	actual := map[string]googleRange{}
+	for _, addr := range addresses {
+		actual[addr.Address] = googleRange{addr.GoogleOwned, addr.GoogleService, addr.GoogleScope, addr.BYOIP}
+	}
+
+	require.Equal(t, map[string]googleRange{
+		// Regional addresses are in the more specific cloud.json ranges
+		"34.19.80.22": {owned: true, service: "Google Cloud", scope: "us-west1"},
+		"34.19.90.10": {owned: true, service: "Google Cloud", scope: "us-west1"},
+
+		// Global addresses are only in goog.json
+		"34.54.244.120": {owned: true},
+
+		// Internal addresses are never BYOIP, even when they use public IPs outside of Google's ranges
+		"11.0.0.10": {},
+	}, actual)
+
+	require.Empty(t, gcp.FilterBYOIPAddresses(addresses))
+}
@@ -0,0 +1,142 @@
+package gcp
+
+import (
+	"cmp"
+	"context"
+	"net/netip"
+	"slices"
+
+	assetpb "cloud.google.com/go/asset/apiv1/assetpb"
+	"google.golang.org/api/option"
+)
+
+// overlapAssetTypes are the asset types holding ranges that are routed within a VPC network
+var overlapAssetTypes = []string{
+	AssetTypeComputeSubnetwork,
+	AssetTypeContainerCluster,
+	AssetTypeComputeGlobalAddress,
+}
+
+// RangeOverlap describes a pair of ranges in different VPC networks that overlap. Project and OtherProject are the
+// project numbers (i.e. projects/123456), the same as Address.Project.
+type RangeOverlap struct {
+	Prefix       string `json:"prefix"`
+	RangeName    string `json:"range_name,omitempty"`
+	ResourceName string `json:"resource_name"`
+	ResourceType string `json:"asset_type"`
+	Network      string `json:"network"`
+	Project      string `json:"project"`
+
+	OtherPrefix       string `json:"other_prefix"`
+	OtherRangeName    string `json:"other_range_name,omitempty"`
+	OtherResourceName string `json:"other_resource_name"`
+	OtherResourceType string `json:"other_asset_type"`
+	OtherNetwork      string `json:"other_network"`
+	OtherProject      string `json:"other_project"`
+
+	// Peered is true when the two networks are directly peered with each other, in which case the overlap
+	// prevents routes for the ranges from being exchanged
+	Peered bool `json:"peered"`
+}
+
+// GetOverlappingRangesFromAssetInventory queries the Cloud Asset Inventory API for subnet, GKE and private services
+// access ranges along with VPC network peerings and returns every pair of ranges in different networks that overlap
+func GetOverlappingRangesFromAssetInventory(ctx context.Context, scope string, opts ...option.ClientOption) ([]*RangeOverlap, error) {
+	addresses, err := GetAddressesFromAssetInventoryWithOptions(ctx, scope, overlapAssetTypes, Options{IncludeRanges: true}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	peerings := map[string][]string{}
+
+	err = searchAllResources(ctx, scope, []string{AssetTypeComputeNetwork}, func(resource *assetpb.ResourceSearchResult) error {
+		network := resource.Name
+		peerings[network] = append(peerings[network], getPeeredNetworks(resource)...)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return FindOverlappingRanges(addresses, peerings), nil
+}
+
+// FindOverlappingRanges returns every pair of ranges in different VPC networks that overlap. Ranges without a known
+// network are ignored. peerings maps the full resource name of a network to the networks it is peered with.
+func FindOverlappingRanges(addresses []*Address, peerings map[string][]string) []*RangeOverlap {
+	type networkRange struct {
+		prefix  netip.Prefix
+		last    netip.Addr
+		address *Address
+	}
+
+	ranges := []networkRange{}
+	for _, addr := range addresses {
+		if !addr.IsRange() || addr.Network == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(addr.Prefix)
+		if err != nil {
+			continue
+		}
+		prefix = prefix.Masked()
+
+		ranges = append(ranges, networkRange{prefix: prefix, last: lastAddr(prefix), address: addr})
+	}
+
+	slices.SortFunc(ranges, func(a, b networkRange) int {
+		return cmp.Or(
+			a.prefix.Addr().Compare(b.prefix.Addr()),
+			cmp.Compare(a.prefix.Bits(), b.prefix.Bits()),
+			cmp.Compare(a.address.ResourceName, b.address.ResourceName),
+		)
+	})
+
+	results := []*RangeOverlap{}
+
+	// Ranges are sorted by their first address so every range overlapping ranges[i] further down the list
+	// starts before ranges[i] ends
+	for i, a := range ranges {
+		for _, b := range ranges[i+1:] {
+			if b.prefix.Addr().Compare(a.last) > 0 {
+				break
+			}
+			if a.address.Network == b.address.Network {
+				continue
+			}
+
+			results = append(results, &RangeOverlap{
+				Prefix:       a.prefix.String(),
+				RangeName:    a.address.RangeName,
+				ResourceName: a.address.ResourceName,
+				ResourceType: a.address.ResourceType,
+				Network:      a.address.Network,
+				Project:      a.address.Project,
+
+				OtherPrefix:       b.prefix.String(),
+				OtherRangeName:    b.address.RangeName,
+				OtherResourceName: b.address.ResourceName,
+				OtherResourceType: b.address.ResourceType,
+				OtherNetwork:      b.address.Network,
+				OtherProject:      b.address.Project,
+
+				Peered: slices.Contains(peerings[a.address.Network], b.address.Network) ||
+					slices.Contains(peerings[b.address.Network], a.address.Network),
+			})
+		}
+	}
+
+	return results
+}
+
+// lastAddr returns the last address in the prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
@@ -0,0 +1,154 @@
+package gcp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestGetOverlappingRanges(t *testing.T) {
+	server, err := setupTestServer()
+	if err != nil {
+		t.Fatalf("error setting up test server: %s", err)
+	}
+	defer server.Close() //nolint:errcheck
+
+	overlaps, err := gcp.GetOverlappingRangesFromAssetInventory(
+		context.Background(),
+		scope,
+
+		// These are necessary to get the Google Cloud SDK to use the fake grpc server
+		option.WithEndpoint(server.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("error getting overlapping ranges from asset inventory: %s", err)
+	}
+
+	overlapSubnet := "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-overlap-subnet"
+	overlapNetwork := "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/ip-list-test-overlap-network"
+	publicNetwork := "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network"
+	defaultNetwork := "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default"
+
+	require.Equal(t, []*gcp.RangeOverlap{
+		{
+			Prefix:            "10.0.0.0/16",
+			ResourceName:      overlapSubnet,
+			ResourceType:      "compute.googleapis.com/Subnetwork",
+			Network:           overlapNetwork,
+			Project:           "projects/828107101350",
+			OtherPrefix:       "10.0.2.0/24",
+			OtherResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/lb-subnet",
+			OtherResourceType: "compute.googleapis.com/Subnetwork",
+			OtherNetwork:      publicNetwork,
+			OtherProject:      "projects/828107101350",
+			Peered:            false,
+		},
+		{
+			Prefix:            "10.0.0.0/16",
+			ResourceName:      overlapSubnet,
+			ResourceType:      "compute.googleapis.com/Subnetwork",
+			Network:           overlapNetwork,
+			Project:           "projects/828107101350",
+			OtherPrefix:       "10.0.3.0/24",
+			OtherResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+			OtherResourceType: "compute.googleapis.com/Subnetwork",
+			OtherNetwork:      publicNetwork,
+			OtherProject:      "projects/828107101350",
+			Peered:            false,
+		},
+		{
+			Prefix:            "10.84.0.0/14",
+			RangeName:         "gke-ip-list-test-cluster-pods-b4fa9d0e",
+			ResourceName:      "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+			ResourceType:      "compute.googleapis.com/Subnetwork",
+			Network:           defaultNetwork,
+			Project:           "projects/828107101350",
+			OtherPrefix:       "10.86.0.0/16",
+			OtherRangeName:    "ip-list-test-overlap-pods",
+			OtherResourceName: overlapSubnet,
+			OtherResourceType: "compute.googleapis.com/Subnetwork",
+			OtherNetwork:      overlapNetwork,
+			OtherProject:      "projects/828107101350",
+			Peered:            false,
+		},
+		{
+			Prefix:            "10.84.0.0/14",
+			ResourceName:      "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+			ResourceType:      "container.googleapis.com/Cluster",
+			Network:           defaultNetwork,
+			Project:           "projects/828107101350",
+			OtherPrefix:       "10.86.0.0/16",
+			OtherRangeName:    "ip-list-test-overlap-pods",
+			OtherResourceName: overlapSubnet,
+			OtherResourceType: "compute.googleapis.com/Subnetwork",
+			OtherNetwork:      overlapNetwork,
+			OtherProject:      "projects/828107101350",
+			Peered:            false,
+		},
+	}, overlaps)
+}
+
+func TestFindOverlappingRanges(t *testing.T) {
+	testAddresses := []*gcp.Address{
+		{
+			Address:      "fd20:9c1:4f3a::",
+			Prefix:       "fd20:9c1:4f3a::/48",
+			ResourceName: "//compute.googleapis.com/projects/a/regions/us-west1/subnetworks/a",
+			Project:      "projects/1",
+			Network:      "//compute.googleapis.com/projects/a/global/networks/a",
+		},
+		{
+			Address:      "fd20:9c1:4f3a:10::",
+			Prefix:       "fd20:9c1:4f3a:10::/64",
+			ResourceName: "//compute.googleapis.com/projects/b/regions/us-west1/subnetworks/b",
+			Project:      "projects/2",
+			Network:      "//compute.googleapis.com/projects/b/global/networks/b",
+		},
+		{
+			// Ranges in the same network can't overlap in practice (i.e. a GKE cluster using a subnet's secondary range)
+			Address:      "fd20:9c1:4f3a:20::",
+			Prefix:       "fd20:9c1:4f3a:20::/64",
+			ResourceName: "//compute.googleapis.com/projects/a/regions/us-west1/subnetworks/a2",
+			Project:      "projects/1",
+			Network:      "//compute.googleapis.com/projects/a/global/networks/a",
+		},
+		{
+			// Ranges without a known network are ignored
+			Address:      "fd20:9c1:4f3a::",
+			Prefix:       "fd20:9c1:4f3a::/64",
+			ResourceName: "//container.googleapis.com/projects/c/locations/us-west1/clusters/c",
+		},
+		{
+			Address:      "fd20:9c1:4f3b::",
+			Prefix:       "fd20:9c1:4f3b::/48",
+			ResourceName: "//compute.googleapis.com/projects/c/regions/us-west1/subnetworks/c",
+			Project:      "projects/3",
+			Network:      "//compute.googleapis.com/projects/c/global/networks/c",
+		},
+	}
+
+	overlaps := gcp.FindOverlappingRanges(testAddresses, map[string][]string{
+		"//compute.googleapis.com/projects/b/global/networks/b": {"//compute.googleapis.com/projects/a/global/networks/a"},
+	})
+
+	require.Equal(t, []*gcp.RangeOverlap{
+		{
+			Prefix:            "fd20:9c1:4f3a::/48",
+			ResourceName:      "//compute.googleapis.com/projects/a/regions/us-west1/subnetworks/a",
+			Network:           "//compute.googleapis.com/projects/a/global/networks/a",
+			Project:           "projects/1",
+			OtherPrefix:       "fd20:9c1:4f3a:10::/64",
+			OtherResourceName: "//compute.googleapis.com/projects/b/regions/us-west1/subnetworks/b",
+			OtherNetwork:      "//compute.googleapis.com/projects/b/global/networks/b",
+			OtherProject:      "projects/2",
+			Peered:            true,
+		},
+	}, overlaps)
+}
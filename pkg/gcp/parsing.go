@@ -1,32 +1,289 @@
 package gcp
 
 import (
+	"fmt"
+	"net/netip"
+	"slices"
 	"strings"
 
 	assetpb "cloud.google.com/go/asset/apiv1/assetpb"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type AddressGetter func(resource *assetpb.ResourceSearchResult) []*Address
 
+// NetworkGetter returns the full resource name of the VPC network of an asset
+type NetworkGetter func(resource *assetpb.ResourceSearchResult) string
+
+// NetworkParentGetter returns the full resource name of the resource holding the VPC network of an asset
+type NetworkParentGetter func(resource *assetpb.ResourceSearchResult) string
+
 const (
-	AssetTypeComputeInstance       = "compute.googleapis.com/Instance"
-	AssetTypeComputeAddress        = "compute.googleapis.com/Address"
-	AssetTypeCloudSQLInstance      = "sqladmin.googleapis.com/Instance"
-	AssetTypeContainerCluster      = "container.googleapis.com/Cluster"
-	AssetTypeComputeForwardingRule = "compute.googleapis.com/ForwardingRule"
-	AssetTypeComputeRouter         = "compute.googleapis.com/Router"
+	AssetTypeComputeInstance               = "compute.googleapis.com/Instance"
+	AssetTypeComputeAddress                = "compute.googleapis.com/Address"
+	AssetTypeComputeGlobalAddress          = "compute.googleapis.com/GlobalAddress"
+	AssetTypeCloudSQLInstance              = "sqladmin.googleapis.com/Instance"
+	AssetTypeContainerCluster              = "container.googleapis.com/Cluster"
+	AssetTypeComputeForwardingRule         = "compute.googleapis.com/ForwardingRule"
+	AssetTypeComputeRouter                 = "compute.googleapis.com/Router"
+	AssetTypeRedisInstance                 = "redis.googleapis.com/Instance"
+	AssetTypeFilestoreInstance             = "file.googleapis.com/Instance"
+	AssetTypeAlloyDBInstance               = "alloydb.googleapis.com/Instance"
+	AssetTypeAlloyDBCluster                = "alloydb.googleapis.com/Cluster"
+	AssetTypeComputeVpnGateway             = "compute.googleapis.com/VpnGateway"
+	AssetTypeComputeTargetVpnGateway       = "compute.googleapis.com/TargetVpnGateway"
+	AssetTypeComputeInterconnectAttachment = "compute.googleapis.com/InterconnectAttachment"
+	AssetTypeKubernetesService             = "k8s.io/Service"
+	AssetTypeKubernetesIngress             = "networking.k8s.io/Ingress"
+	AssetTypeKubernetesGateway             = "gateway.networking.k8s.io/Gateway"
+	AssetTypeComputeSubnetwork             = "compute.googleapis.com/Subnetwork"
+	AssetTypeComputeNetwork                = "compute.googleapis.com/Network"
 )
 
 var getAddressByAssetType = map[string]AddressGetter{
-	AssetTypeComputeInstance:       getAddressForGCEInstance,
-	AssetTypeComputeAddress:        getAddressForAddress,
-	AssetTypeCloudSQLInstance:      getAddressForSQLInstances,
-	AssetTypeContainerCluster:      getAddressForGKECluster,
-	AssetTypeComputeForwardingRule: getAddressForForwardingRule,
-	AssetTypeComputeRouter:         getAddressForRouter,
+	AssetTypeComputeInstance:               getAddressForGCEInstance,
+	AssetTypeComputeAddress:                getAddressForAddress,
+	AssetTypeComputeGlobalAddress:          getAddressForAddress,
+	AssetTypeCloudSQLInstance:              getAddressForSQLInstances,
+	AssetTypeContainerCluster:              getAddressForGKECluster,
+	AssetTypeComputeForwardingRule:         getAddressForForwardingRule,
+	AssetTypeComputeRouter:                 getAddressForRouter,
+	AssetTypeRedisInstance:                 getAddressForRedisInstance,
+	AssetTypeFilestoreInstance:             getAddressForFilestoreInstance,
+	AssetTypeAlloyDBInstance:               getAddressForAlloyDBInstance,
+	AssetTypeComputeVpnGateway:             getAddressForVpnGateway,
+	AssetTypeComputeTargetVpnGateway:       getAddressForTargetVpnGateway,
+	AssetTypeComputeInterconnectAttachment: getAddressForInterconnectAttachment,
+	AssetTypeKubernetesService:             getAddressForKubernetesService,
+	AssetTypeKubernetesIngress:             getAddressForKubernetesIngress,
+	AssetTypeKubernetesGateway:             getAddressForKubernetesGateway,
+	AssetTypeComputeSubnetwork:             getAddressForSubnetwork,
+}
+
+// optInAssetTypes are supported asset types that aren't searched by GetAllAddressesFromAssetInventory unless they are
+// enabled in Options. Cloud Asset Inventory doesn't list Gateway API resources as searchable asset types, so searching
+// for them could fail the whole query.
+var optInAssetTypes = []string{
+	AssetTypeKubernetesGateway,
+}
+
+// getNetworkByAssetType holds the network getters of the assets that other assets are allocated from without repeating
+// their network (i.e. AlloyDB instances only know their cluster, internal addresses only know their subnetwork and
+// Kubernetes resources only know their GKE cluster)
+var getNetworkByAssetType = map[string]NetworkGetter{
+	AssetTypeAlloyDBCluster:    getNetworkForAlloyDBCluster,
+	AssetTypeComputeSubnetwork: getNetworkForSubnetwork,
+	AssetTypeContainerCluster:  getNetworkForGKECluster,
+	AssetTypeComputeRouter:     getNetworkForRouter,
+}
+
+// networkParentAssetTypes maps asset types whose private addresses don't know their VPC network to the asset type of
+// the parent resource holding it
+var networkParentAssetTypes = map[string]string{
+	AssetTypeAlloyDBInstance:               AssetTypeAlloyDBCluster,
+	AssetTypeComputeAddress:                AssetTypeComputeSubnetwork,
+	AssetTypeKubernetesService:             AssetTypeContainerCluster,
+	AssetTypeKubernetesIngress:             AssetTypeContainerCluster,
+	AssetTypeKubernetesGateway:             AssetTypeContainerCluster,
+	AssetTypeComputeInterconnectAttachment: AssetTypeComputeRouter,
+}
+
+// getNetworkParentByAssetType holds the getters of the parent resource holding the VPC network for the asset types whose
+// parent isn't otherwise recorded on their addresses (i.e. the Cloud Router of an Interconnect attachment)
+var getNetworkParentByAssetType = map[string]NetworkParentGetter{
+	AssetTypeComputeInterconnectAttachment: getRouterForInterconnectAttachment,
+}
+
+// referencedAssetTypes maps asset types that only reference the resource holding their IP address
+// to the asset type of the referenced resource
+var referencedAssetTypes = map[string]string{
+	AssetTypeComputeRouter:           AssetTypeComputeAddress,
+	AssetTypeComputeTargetVpnGateway: AssetTypeComputeForwardingRule,
+}
+
+// selfLinkToResourceName converts a Compute Engine API self link to the full resource name used by Cloud Asset Inventory
+func selfLinkToResourceName(selfLink string) string {
+	return strings.Replace(selfLink, "https://www.googleapis.com/compute/v1/", "//compute.googleapis.com/", 1)
+}
+
+// addressFromCIDR strips the prefix length from an address in CIDR notation (i.e. 169.254.0.1/29)
+func addressFromCIDR(cidr string) string {
+	address, _, _ := strings.Cut(cidr, "/")
+	return address
+}
+
+// normalizeIP converts an IP address to its canonical form (i.e. 2600:1900:0:0:0:0:0:1 becomes 2600:1900::1),
+// returning it unchanged if it isn't a valid IP address
+func normalizeIP(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	return addr.String()
+}
+
+// addressScope determines whether a static address or forwarding rule is global or regional based on its location
+func addressScope(resource *assetpb.ResourceSearchResult) string {
+	if resource.Location == "global" {
+		return AddressScopeGlobal
+	}
+	return AddressScopeRegional
+}
+
+// kubernetesClusterAndNamespace splits the full resource name of a namespaced Kubernetes resource
+// (i.e. //container.googleapis.com/projects/p/locations/l/clusters/c/k8s/namespaces/ns/services/s)
+// into the full resource name of the owning cluster and the namespace
+func kubernetesClusterAndNamespace(name string) (string, string) {
+	cluster, path, ok := strings.Cut(name, "/k8s/")
+	if !ok {
+		return "", ""
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[0] != "namespaces" {
+		return cluster, ""
+	}
+
+	return cluster, parts[1]
+}
+
+// rangeAddress builds an Address for an allocated range in CIDR notation, returning nil if the range is invalid
+func rangeAddress(resource *assetpb.ResourceSearchResult, cidr string) *Address {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil
+	}
+
+	prefix = prefix.Masked()
+	address := prefix.Addr().String()
+
+	return &Address{
+		Address:      address,
+		Prefix:       prefix.String(),
+		ResourceName: resource.Name,
+		AddressType:  ipType(address),
+		ResourceType: resource.AssetType,
+	}
+}
+
+// normalizeNetwork converts a VPC network self link (https://www.googleapis.com/compute/v1/projects/p/global/networks/n)
+// or relative name (projects/p/global/networks/n) to its full resource name
+func normalizeNetwork(network string) string {
+	if strings.HasPrefix(network, "projects/") {
+		return "//compute.googleapis.com/" + network
+	}
+	return selfLinkToResourceName(network)
+}
+
+// projectFromResourceName returns the project ID from a full resource name or an empty string if there isn't one
+func projectFromResourceName(name string) string {
+	_, path, ok := strings.Cut(name, "/projects/")
+	if !ok {
+		return ""
+	}
+
+	project, _, _ := strings.Cut(path, "/")
+	return project
 }
 
 func getAddressForGCEInstance(resource *assetpb.ResourceSearchResult) []*Address {
+	instanceResources := resource.GetVersionedResources()
+	if len(instanceResources) == 0 || instanceResources[0].GetResource() == nil {
+		return getAddressForGCEInstanceFromAttributes(resource)
+	}
+
+	addresses := []*Address{}
+
+	for _, networkInterface := range instanceResources[0].GetResource().GetFields()["networkInterfaces"].GetListValue().GetValues() {
+		addresses = append(addresses, getAddressForNetworkInterface(resource, networkInterface.GetStructValue())...)
+	}
+
+	return addresses
+}
+
+// getAddressForNetworkInterface returns the internal and external IPv4 and IPv6 addresses of an instance's network interface,
+// annotated with the interface and the access config each external address comes from
+func getAddressForNetworkInterface(resource *assetpb.ResourceSearchResult, networkInterface *structpb.Struct) []*Address {
+	interfaceFields := networkInterface.GetFields()
+
+	addresses := []*Address{}
+
+	newAddress := func(ip string) *Address {
+		ip = normalizeIP(ip)
+		return &Address{
+			Address:      ip,
+			ResourceName: resource.Name,
+			AddressType:  ipType(ip),
+			ResourceType: resource.AssetType,
+		}
+	}
+
+	if ip := interfaceFields["networkIP"].GetStringValue(); ip != "" {
+		addr := newAddress(ip)
+		setInternal(addr)
+		addresses = append(addresses, addr)
+	}
+
+	for _, accessConfig := range interfaceFields["accessConfigs"].GetListValue().GetValues() {
+		configFields := accessConfig.GetStructValue().GetFields()
+
+		if ip := configFields["natIP"].GetStringValue(); ip != "" {
+			addr := newAddress(ip)
+			addr.AccessConfig = configFields["name"].GetStringValue()
+			addr.NetworkTier = configFields["networkTier"].GetStringValue()
+			addresses = append(addresses, addr)
+		}
+	}
+
+	if ip := interfaceFields["ipv6Address"].GetStringValue(); ip != "" {
+		addresses = append(addresses, newAddress(ip))
+	}
+
+	for _, accessConfig := range interfaceFields["ipv6AccessConfigs"].GetListValue().GetValues() {
+		configFields := accessConfig.GetStructValue().GetFields()
+
+		if ip := configFields["externalIpv6"].GetStringValue(); ip != "" {
+			addr := newAddress(ip)
+			addr.AccessConfig = configFields["name"].GetStringValue()
+			addr.NetworkTier = configFields["networkTier"].GetStringValue()
+			addresses = append(addresses, addr)
+		}
+	}
+
+	aliasIPRanges := []string{}
+	for _, aliasIPRange := range interfaceFields["aliasIpRanges"].GetListValue().GetValues() {
+		rangeFields := aliasIPRange.GetStructValue().GetFields()
+
+		cidr := rangeFields["ipCidrRange"].GetStringValue()
+		if cidr == "" {
+			continue
+		}
+		aliasIPRanges = append(aliasIPRanges, cidr)
+
+		// Alias ranges are allocated from the primary range or the named secondary range of the interface's subnet
+		if addr := rangeAddress(resource, cidr); addr != nil {
+			addr.RangeName = rangeFields["subnetworkRangeName"].GetStringValue()
+			setInternal(addr)
+			addresses = append(addresses, addr)
+		}
+	}
+
+	for _, addr := range addresses {
+		addr.NetworkInterface = interfaceFields["name"].GetStringValue()
+		addr.Network = normalizeNetwork(interfaceFields["network"].GetStringValue())
+		addr.Subnetwork = selfLinkToResourceName(interfaceFields["subnetwork"].GetStringValue())
+
+		if len(aliasIPRanges) > 0 {
+			addr.AliasIPRanges = aliasIPRanges
+		}
+	}
+
+	return addresses
+}
+
+// getAddressForGCEInstanceFromAttributes falls back to the flattened externalIPs and internalIPs attributes
+// for instances without a versioned resource
+func getAddressForGCEInstanceFromAttributes(resource *assetpb.ResourceSearchResult) []*Address {
 	ipStrings := []string{}
 
 	externalIPs := resource.AdditionalAttributes.Fields["externalIPs"]
@@ -73,7 +330,13 @@ func getAddressForGCEInstance(resource *assetpb.ResourceSearchResult) []*Address
 }
 
 func getAddressForAddress(resource *assetpb.ResourceSearchResult) []*Address {
-	if resource.State != "IN_USE" {
+	// Private services access allocations are reserved ranges rather than single addresses
+	if addr := getRangeForPrivateServicesAccess(resource); addr != nil {
+		return []*Address{addr}
+	}
+
+	// Reserved addresses aren't attached to anything, they are only returned when Options.IncludeReserved is set
+	if resource.State != "IN_USE" && resource.State != "RESERVED" {
 		return nil
 	}
 
@@ -87,14 +350,57 @@ func getAddressForAddress(resource *assetpb.ResourceSearchResult) []*Address {
 		return nil
 	}
 
-	return []*Address{
-		{
-			Address:      addressStr,
-			ResourceName: resource.Name,
-			AddressType:  ipType(addressStr),
-			ResourceType: resource.AssetType,
-		},
+	addr := &Address{
+		Address:      addressStr,
+		ResourceName: resource.Name,
+		AddressType:  ipType(addressStr),
+		ResourceType: resource.AssetType,
+		AddressScope: addressScope(resource),
+	}
+
+	// Only internal addresses that aren't tied to a subnetwork (i.e. Private Service Connect endpoints) have a network,
+	// the network of the others is set from their subnetwork (see setParentNetworks). Only external addresses have a
+	// network tier.
+	if addressResources := resource.GetVersionedResources(); len(addressResources) > 0 {
+		addressFields := addressResources[0].GetResource().GetFields()
+
+		addr.Network = normalizeNetwork(addressFields["network"].GetStringValue())
+		addr.Subnetwork = selfLinkToResourceName(addressFields["subnetwork"].GetStringValue())
+		if addressFields["addressType"].GetStringValue() == "EXTERNAL" {
+			addr.NetworkTier = addressFields["networkTier"].GetStringValue()
+		} else {
+			setInternal(addr)
+		}
+	}
+
+	return []*Address{addr}
+}
+
+// getRangeForPrivateServicesAccess returns the allocated range for a VPC_PEERING address (used for private services access)
+// or nil if the address is not a private services access allocation
+func getRangeForPrivateServicesAccess(resource *assetpb.ResourceSearchResult) *Address {
+	addressResources := resource.GetVersionedResources()
+	if len(addressResources) == 0 {
+		return nil
+	}
+
+	addressFields := addressResources[0].GetResource().GetFields()
+	if addressFields["purpose"].GetStringValue() != "VPC_PEERING" {
+		return nil
+	}
+
+	cidr := fmt.Sprintf("%s/%d", addressFields["address"].GetStringValue(), int(addressFields["prefixLength"].GetNumberValue()))
+
+	addr := rangeAddress(resource, cidr)
+	if addr == nil {
+		return nil
 	}
+
+	addr.AddressScope = addressScope(resource)
+	addr.Network = normalizeNetwork(addressFields["network"].GetStringValue())
+	setInternal(addr)
+
+	return addr
 }
 
 func getAddressForSQLInstances(resource *assetpb.ResourceSearchResult) []*Address {
@@ -119,6 +425,8 @@ func getAddressForSQLInstances(resource *assetpb.ResourceSearchResult) []*Addres
 
 	publicAddresses := []*Address{}
 
+	privateNetwork := dbResourceValues.GetFields()["settings"].GetStructValue().GetFields()["ipConfiguration"].GetStructValue().GetFields()["privateNetwork"].GetStringValue()
+
 	for _, address := range addressesListValues {
 		addressFields := address.GetStructValue().GetFields()
 		typeValue := addressFields["type"].GetStringValue()
@@ -128,13 +436,20 @@ func getAddressForSQLInstances(resource *assetpb.ResourceSearchResult) []*Addres
 		}
 
 		addressValue := addressFields["ipAddress"].GetStringValue()
-		publicAddresses = append(publicAddresses, &Address{
+		addr := &Address{
 			Address:      addressValue,
 			ResourceName: resource.Name,
 			AddressType:  ipType(addressValue),
 			ResourceType: resource.AssetType,
-		})
+		}
+
+		// Private IPs are allocated from the private services access range of the VPC network, which can be PUPI space
+		if typeValue == "PRIVATE" {
+			addr.Network = normalizeNetwork(privateNetwork)
+			setInternal(addr)
+		}
 
+		publicAddresses = append(publicAddresses, addr)
 	}
 
 	return publicAddresses
@@ -182,9 +497,59 @@ func getAddressForGKECluster(resource *assetpb.ResourceSearchResult) []*Address
 		})
 	}
 
+	// Pod, service and control plane ranges are only returned when ranges are requested
+	rangeAddresses := []*Address{}
+	clusterFields := clusterResourceValues.GetFields()
+	cidrs := []string{
+		clusterFields["clusterIpv4Cidr"].GetStringValue(),
+		clusterFields["servicesIpv4Cidr"].GetStringValue(),
+		privateClusterConfig.GetFields()["masterIpv4CidrBlock"].GetStringValue(),
+	}
+
+	for _, pool := range clusterFields["nodePools"].GetListValue().GetValues() {
+		networkConfig := pool.GetStructValue().GetFields()["networkConfig"].GetStructValue()
+		cidrs = append(cidrs, networkConfig.GetFields()["podIpv4CidrBlock"].GetStringValue())
+	}
+
+	seenCIDRs := []string{}
+
+	for _, cidr := range cidrs {
+		if cidr == "" || slices.Contains(seenCIDRs, cidr) {
+			continue
+		}
+		seenCIDRs = append(seenCIDRs, cidr)
+
+		if addr := rangeAddress(resource, cidr); addr != nil {
+			rangeAddresses = append(rangeAddresses, addr)
+		}
+	}
+
+	// The ranges are only routed inside of the VPC network, even when they use public IPs (i.e. GKE's default
+	// service range 34.118.224.0/20)
+	for _, addr := range rangeAddresses {
+		setInternal(addr)
+	}
+	addresses = append(addresses, rangeAddresses...)
+
+	network := getNetworkForGKECluster(resource)
+	for _, addr := range addresses {
+		addr.Network = network
+	}
+
 	return addresses
 }
 
+func getNetworkForGKECluster(resource *assetpb.ResourceSearchResult) string {
+	clusterResources := resource.GetVersionedResources()
+	if len(clusterResources) == 0 || clusterResources[0].GetResource() == nil {
+		return ""
+	}
+
+	clusterFields := clusterResources[0].GetResource().GetFields()
+
+	return normalizeNetwork(clusterFields["networkConfig"].GetStructValue().GetFields()["network"].GetStringValue())
+}
+
 func getAddressForForwardingRule(resource *assetpb.ResourceSearchResult) []*Address {
 	ruleResources := resource.GetVersionedResources()
 	if len(ruleResources) == 0 {
@@ -207,16 +572,26 @@ func getAddressForForwardingRule(resource *assetpb.ResourceSearchResult) []*Addr
 		return nil
 	}
 
-	address := addressField.GetStringValue()
+	// IPv6 forwarding rules hold a range (i.e. 2600:1900:4040:2b1:8000:0:0:0/96) rather than a single address
+	address := normalizeIP(addressFromCIDR(addressField.GetStringValue()))
 
-	return []*Address{
-		{
-			Address:      address,
-			ResourceName: resource.Name,
-			AddressType:  ipType(address),
-			ResourceType: resource.AssetType,
-		},
+	addr := &Address{
+		Address:      address,
+		ResourceName: resource.Name,
+		AddressType:  ipType(address),
+		ResourceType: resource.AssetType,
+		AddressScope: addressScope(resource),
+
+		// Only internal forwarding rules belong to a VPC network
+		Network: normalizeNetwork(ruleResourceValues.GetFields()["network"].GetStringValue()),
 	}
+
+	// Internal load balancers use the INTERNAL or INTERNAL_MANAGED (and INTERNAL_SELF_MANAGED) schemes
+	if strings.HasPrefix(ruleResourceValues.GetFields()["loadBalancingScheme"].GetStringValue(), "INTERNAL") {
+		setInternal(addr)
+	}
+
+	return []*Address{addr}
 }
 
 func getAddressForRouter(resource *assetpb.ResourceSearchResult) []*Address {
@@ -235,18 +610,11 @@ func getAddressForRouter(resource *assetpb.ResourceSearchResult) []*Address {
 		return nil
 	}
 
-	natsField := routerResourceValues.GetFields()["nats"]
-
-	if natsField == nil || natsField.GetListValue() == nil {
-		return nil
-	}
-
-	natsList := natsField.GetListValue()
-	natsListValues := natsList.GetValues()
-
 	addresses := []*Address{}
 
-	for _, nat := range natsListValues {
+	natsField := routerResourceValues.GetFields()["nats"]
+
+	for _, nat := range natsField.GetListValue().GetValues() {
 		natFields := nat.GetStructValue().GetFields()
 		natIpsField := natFields["natIps"]
 		if natIpsField == nil || natIpsField.GetListValue() == nil {
@@ -257,13 +625,605 @@ func getAddressForRouter(resource *assetpb.ResourceSearchResult) []*Address {
 		for _, ip := range natIpsFieldList.GetValues() {
 			ref := ip.GetStringValue()
 			addresses = append(addresses, &Address{
-				Address:      strings.Replace(ref, "https://www.googleapis.com/compute/v1/", "//compute.googleapis.com/", 1),
+				Address:      selfLinkToResourceName(ref),
 				ResourceName: resource.Name,
 				AddressType:  AddressTypeReference,
 				ResourceType: resource.AssetType,
+				Reference:    ref,
 			})
 		}
 	}
 
+	// Routers used for Interconnect or HA VPN also have link-local BGP interface and peer addresses
+	ipStrings := []string{}
+
+	interfacesField := routerResourceValues.GetFields()["interfaces"]
+	for _, iface := range interfacesField.GetListValue().GetValues() {
+		ipRange := iface.GetStructValue().GetFields()["ipRange"].GetStringValue()
+		if ipRange != "" {
+			ipStrings = append(ipStrings, addressFromCIDR(ipRange))
+		}
+	}
+
+	bgpPeersField := routerResourceValues.GetFields()["bgpPeers"]
+	for _, peer := range bgpPeersField.GetListValue().GetValues() {
+		peerAddress := peer.GetStructValue().GetFields()["peerIpAddress"].GetStringValue()
+		if peerAddress != "" {
+			ipStrings = append(ipStrings, peerAddress)
+		}
+	}
+
+	network := getNetworkForRouter(resource)
+
+	for _, ip := range ipStrings {
+		addresses = append(addresses, &Address{
+			Address:      ip,
+			ResourceName: resource.Name,
+			AddressType:  ipType(ip),
+			ResourceType: resource.AssetType,
+			Network:      network,
+		})
+	}
+
+	return addresses
+}
+
+func getNetworkForRouter(resource *assetpb.ResourceSearchResult) string {
+	routerResources := resource.GetVersionedResources()
+	if len(routerResources) == 0 || routerResources[0].GetResource() == nil {
+		return ""
+	}
+
+	return normalizeNetwork(routerResources[0].GetResource().GetFields()["network"].GetStringValue())
+}
+
+func getAddressForRedisInstance(resource *assetpb.ResourceSearchResult) []*Address {
+	redisResources := resource.GetVersionedResources()
+	if len(redisResources) == 0 {
+		return nil
+	}
+
+	redisResource := redisResources[0]
+	if redisResource == nil {
+		return nil
+	}
+
+	redisResourceValues := redisResource.GetResource()
+	if redisResourceValues == nil {
+		return nil
+	}
+
+	ipStrings := []string{}
+
+	// host is the primary endpoint, readEndpoint is only set when read replicas are enabled
+	for _, field := range []string{"host", "readEndpoint"} {
+		value := redisResourceValues.GetFields()[field].GetStringValue()
+		if value != "" {
+			ipStrings = append(ipStrings, value)
+		}
+	}
+
+	addresses := []*Address{}
+
+	network := normalizeNetwork(redisResourceValues.GetFields()["authorizedNetwork"].GetStringValue())
+
+	// Redis endpoints are allocated from a reserved range of the authorized network, which can be PUPI space
+	for _, ip := range ipStrings {
+		addr := &Address{
+			Address:      ip,
+			ResourceName: resource.Name,
+			ResourceType: resource.AssetType,
+			Network:      network,
+		}
+		setInternal(addr)
+		addresses = append(addresses, addr)
+	}
+
+	return addresses
+}
+
+func getAddressForFilestoreInstance(resource *assetpb.ResourceSearchResult) []*Address {
+	fileResources := resource.GetVersionedResources()
+	if len(fileResources) == 0 {
+		return nil
+	}
+
+	fileResource := fileResources[0]
+	if fileResource == nil {
+		return nil
+	}
+
+	fileResourceValues := fileResource.GetResource()
+	if fileResourceValues == nil {
+		return nil
+	}
+
+	networksField := fileResourceValues.GetFields()["networks"]
+
+	if networksField == nil || networksField.GetListValue() == nil {
+		return nil
+	}
+
+	addresses := []*Address{}
+
+	for _, network := range networksField.GetListValue().GetValues() {
+		networkFields := network.GetStructValue().GetFields()
+		ipAddressesField := networkFields["ipAddresses"]
+		if ipAddressesField == nil || ipAddressesField.GetListValue() == nil {
+			continue
+		}
+
+		// The network is usually just the name of a network in the instance's project
+		networkName := networkFields["network"].GetStringValue()
+		if networkName != "" && !strings.Contains(networkName, "/") {
+			networkName = "projects/" + projectFromResourceName(resource.Name) + "/global/networks/" + networkName
+		}
+		network := normalizeNetwork(networkName)
+
+		for _, ip := range ipAddressesField.GetListValue().GetValues() {
+			address := ip.GetStringValue()
+			if address == "" {
+				continue
+			}
+
+			// Filestore instances are only reachable from inside the network they are connected to
+			addr := &Address{
+				Address:      address,
+				ResourceName: resource.Name,
+				ResourceType: resource.AssetType,
+				Network:      network,
+			}
+			setInternal(addr)
+			addresses = append(addresses, addr)
+		}
+	}
+
 	return addresses
 }
+
+func getAddressForAlloyDBInstance(resource *assetpb.ResourceSearchResult) []*Address {
+	dbResources := resource.GetVersionedResources()
+	if len(dbResources) == 0 {
+		return nil
+	}
+
+	dbResource := dbResources[0]
+	if dbResource == nil {
+		return nil
+	}
+
+	dbResourceValues := dbResource.GetResource()
+	if dbResourceValues == nil {
+		return nil
+	}
+
+	addresses := []*Address{}
+
+	// Primary and read pool instances share the same shape, each with their own private (and optionally public) IP.
+	// The private IP is allocated from the private services access range (which can be PUPI space) and its network is
+	// set from the cluster.
+	if ip := dbResourceValues.GetFields()["ipAddress"].GetStringValue(); ip != "" {
+		addr := &Address{
+			Address:      ip,
+			ResourceName: resource.Name,
+			ResourceType: resource.AssetType,
+		}
+		setInternal(addr)
+		addresses = append(addresses, addr)
+	}
+
+	ipStrings := []string{}
+
+	if ip := dbResourceValues.GetFields()["publicIpAddress"].GetStringValue(); ip != "" {
+		ipStrings = append(ipStrings, ip)
+	}
+
+	// Outbound public IPs are only used for egress, but they are what third parties allowlist so they are listed too
+	outboundField := dbResourceValues.GetFields()["outboundPublicIpAddresses"]
+	for _, ip := range outboundField.GetListValue().GetValues() {
+		value := ip.GetStringValue()
+		if value != "" {
+			ipStrings = append(ipStrings, value)
+		}
+	}
+
+	for _, ip := range ipStrings {
+		addresses = append(addresses, &Address{
+			Address:      ip,
+			ResourceName: resource.Name,
+			AddressType:  ipType(ip),
+			ResourceType: resource.AssetType,
+		})
+	}
+
+	return addresses
+}
+
+func getNetworkForAlloyDBCluster(resource *assetpb.ResourceSearchResult) string {
+	clusterResources := resource.GetVersionedResources()
+	if len(clusterResources) == 0 || clusterResources[0].GetResource() == nil {
+		return ""
+	}
+
+	clusterFields := clusterResources[0].GetResource().GetFields()
+
+	// The top-level network field is deprecated in favor of networkConfig.network
+	network := clusterFields["networkConfig"].GetStructValue().GetFields()["network"].GetStringValue()
+	if network == "" {
+		network = clusterFields["network"].GetStringValue()
+	}
+
+	return normalizeNetwork(network)
+}
+
+func getNetworkForSubnetwork(resource *assetpb.ResourceSearchResult) string {
+	subnetResources := resource.GetVersionedResources()
+	if len(subnetResources) == 0 || subnetResources[0].GetResource() == nil {
+		return ""
+	}
+
+	return normalizeNetwork(subnetResources[0].GetResource().GetFields()["network"].GetStringValue())
+}
+
+func getAddressForVpnGateway(resource *assetpb.ResourceSearchResult) []*Address {
+	gatewayResources := resource.GetVersionedResources()
+	if len(gatewayResources) == 0 {
+		return nil
+	}
+
+	gatewayResource := gatewayResources[0]
+	if gatewayResource == nil {
+		return nil
+	}
+
+	gatewayResourceValues := gatewayResource.GetResource()
+	if gatewayResourceValues == nil {
+		return nil
+	}
+
+	interfacesField := gatewayResourceValues.GetFields()["vpnInterfaces"]
+
+	if interfacesField == nil || interfacesField.GetListValue() == nil {
+		return nil
+	}
+
+	addresses := []*Address{}
+
+	for _, iface := range interfacesField.GetListValue().GetValues() {
+		address := iface.GetStructValue().GetFields()["ipAddress"].GetStringValue()
+		if address == "" {
+			continue
+		}
+
+		addresses = append(addresses, &Address{
+			Address:      address,
+			ResourceName: resource.Name,
+			AddressType:  ipType(address),
+			ResourceType: resource.AssetType,
+		})
+	}
+
+	return addresses
+}
+
+func getAddressForTargetVpnGateway(resource *assetpb.ResourceSearchResult) []*Address {
+	gatewayResources := resource.GetVersionedResources()
+	if len(gatewayResources) == 0 {
+		return nil
+	}
+
+	gatewayResource := gatewayResources[0]
+	if gatewayResource == nil {
+		return nil
+	}
+
+	gatewayResourceValues := gatewayResource.GetResource()
+	if gatewayResourceValues == nil {
+		return nil
+	}
+
+	// Classic VPN gateways get their IP from the forwarding rules pointing at them, so we only
+	// record a reference to each forwarding rule here and resolve the IP later
+	rulesField := gatewayResourceValues.GetFields()["forwardingRules"]
+
+	if rulesField == nil || rulesField.GetListValue() == nil {
+		return nil
+	}
+
+	addresses := []*Address{}
+
+	for _, rule := range rulesField.GetListValue().GetValues() {
+		ref := rule.GetStringValue()
+		if ref == "" {
+			continue
+		}
+
+		addresses = append(addresses, &Address{
+			Address:      selfLinkToResourceName(ref),
+			ResourceName: resource.Name,
+			AddressType:  AddressTypeReference,
+			ResourceType: resource.AssetType,
+			Reference:    ref,
+		})
+	}
+
+	return addresses
+}
+
+func getAddressForInterconnectAttachment(resource *assetpb.ResourceSearchResult) []*Address {
+	attachmentResources := resource.GetVersionedResources()
+	if len(attachmentResources) == 0 {
+		return nil
+	}
+
+	attachmentResource := attachmentResources[0]
+	if attachmentResource == nil {
+		return nil
+	}
+
+	attachmentResourceValues := attachmentResource.GetResource()
+	if attachmentResourceValues == nil {
+		return nil
+	}
+
+	ipStrings := []string{}
+
+	// Both fields are link-local addresses in CIDR notation (i.e. 169.254.0.1/29)
+	for _, field := range []string{"cloudRouterIpAddress", "customerRouterIpAddress"} {
+		value := attachmentResourceValues.GetFields()[field].GetStringValue()
+		if value != "" {
+			ipStrings = append(ipStrings, addressFromCIDR(value))
+		}
+	}
+
+	addresses := []*Address{}
+
+	for _, ip := range ipStrings {
+		addresses = append(addresses, &Address{
+			Address:      ip,
+			ResourceName: resource.Name,
+			AddressType:  ipType(ip),
+			ResourceType: resource.AssetType,
+		})
+	}
+
+	return addresses
+}
+
+// getRouterForInterconnectAttachment returns the full resource name of the Cloud Router an Interconnect attachment uses
+func getRouterForInterconnectAttachment(resource *assetpb.ResourceSearchResult) string {
+	attachmentResources := resource.GetVersionedResources()
+	if len(attachmentResources) == 0 || attachmentResources[0].GetResource() == nil {
+		return ""
+	}
+
+	return selfLinkToResourceName(attachmentResources[0].GetResource().GetFields()["router"].GetStringValue())
+}
+
+func getAddressForKubernetesService(resource *assetpb.ResourceSearchResult) []*Address {
+	serviceResources := resource.GetVersionedResources()
+	if len(serviceResources) == 0 {
+		return nil
+	}
+
+	serviceResource := serviceResources[0]
+	if serviceResource == nil {
+		return nil
+	}
+
+	serviceResourceValues := serviceResource.GetResource()
+	if serviceResourceValues == nil {
+		return nil
+	}
+
+	ipStrings := []string{}
+
+	// Headless services have a clusterIP of "None"
+	spec := serviceResourceValues.GetFields()["spec"].GetStructValue()
+	clusterIP := spec.GetFields()["clusterIP"].GetStringValue()
+	if clusterIP != "" && clusterIP != "None" {
+		ipStrings = append(ipStrings, clusterIP)
+	}
+
+	status := serviceResourceValues.GetFields()["status"].GetStructValue()
+	ipStrings = append(ipStrings, loadBalancerIngressIPs(status)...)
+
+	addresses := kubernetesAddresses(resource, ipStrings)
+
+	// The cluster IP is a virtual IP that is only reachable from inside the cluster, even when it comes from a public
+	// service range
+	for _, addr := range addresses {
+		if addr.Address == clusterIP {
+			setInternal(addr)
+		}
+	}
+
+	return addresses
+}
+
+func getAddressForKubernetesIngress(resource *assetpb.ResourceSearchResult) []*Address {
+	ingressResources := resource.GetVersionedResources()
+	if len(ingressResources) == 0 {
+		return nil
+	}
+
+	ingressResource := ingressResources[0]
+	if ingressResource == nil {
+		return nil
+	}
+
+	ingressResourceValues := ingressResource.GetResource()
+	if ingressResourceValues == nil {
+		return nil
+	}
+
+	status := ingressResourceValues.GetFields()["status"].GetStructValue()
+
+	return kubernetesAddresses(resource, loadBalancerIngressIPs(status))
+}
+
+func getAddressForKubernetesGateway(resource *assetpb.ResourceSearchResult) []*Address {
+	gatewayResources := resource.GetVersionedResources()
+	if len(gatewayResources) == 0 {
+		return nil
+	}
+
+	gatewayResource := gatewayResources[0]
+	if gatewayResource == nil {
+		return nil
+	}
+
+	gatewayResourceValues := gatewayResource.GetResource()
+	if gatewayResourceValues == nil {
+		return nil
+	}
+
+	ipStrings := []string{}
+
+	status := gatewayResourceValues.GetFields()["status"].GetStructValue()
+	for _, address := range status.GetFields()["addresses"].GetListValue().GetValues() {
+		addressFields := address.GetStructValue().GetFields()
+
+		// The address type defaults to IPAddress when unset, hostnames are skipped
+		addressType := addressFields["type"].GetStringValue()
+		if addressType != "" && addressType != "IPAddress" {
+			continue
+		}
+
+		value := addressFields["value"].GetStringValue()
+		if value != "" {
+			ipStrings = append(ipStrings, value)
+		}
+	}
+
+	return kubernetesAddresses(resource, ipStrings)
+}
+
+// loadBalancerIngressIPs returns the IPs from the status.loadBalancer.ingress list shared by Services and Ingresses
+func loadBalancerIngressIPs(status *structpb.Struct) []string {
+	ipStrings := []string{}
+
+	loadBalancer := status.GetFields()["loadBalancer"].GetStructValue()
+	for _, ingress := range loadBalancer.GetFields()["ingress"].GetListValue().GetValues() {
+		ip := ingress.GetStructValue().GetFields()["ip"].GetStringValue()
+		if ip != "" {
+			ipStrings = append(ipStrings, ip)
+		}
+	}
+
+	return ipStrings
+}
+
+// kubernetesAddresses builds the addresses for a namespaced Kubernetes resource, tagged with its owning cluster and namespace
+func kubernetesAddresses(resource *assetpb.ResourceSearchResult, ipStrings []string) []*Address {
+	cluster, namespace := kubernetesClusterAndNamespace(resource.Name)
+
+	addresses := []*Address{}
+
+	for _, ip := range ipStrings {
+		addresses = append(addresses, &Address{
+			Address:             ip,
+			ResourceName:        resource.Name,
+			AddressType:         ipType(ip),
+			ResourceType:        resource.AssetType,
+			KubernetesCluster:   cluster,
+			KubernetesNamespace: namespace,
+		})
+	}
+
+	return addresses
+}
+
+func getAddressForSubnetwork(resource *assetpb.ResourceSearchResult) []*Address {
+	subnetResources := resource.GetVersionedResources()
+	if len(subnetResources) == 0 {
+		return nil
+	}
+
+	subnetResource := subnetResources[0]
+	if subnetResource == nil {
+		return nil
+	}
+
+	subnetResourceValues := subnetResource.GetResource()
+	if subnetResourceValues == nil {
+		return nil
+	}
+
+	subnetFields := subnetResourceValues.GetFields()
+	addresses := []*Address{}
+
+	gatewayAddress := subnetFields["gatewayAddress"].GetStringValue()
+	if gatewayAddress != "" {
+		addresses = append(addresses, &Address{
+			Address:      gatewayAddress,
+			ResourceName: resource.Name,
+			AddressType:  ipType(gatewayAddress),
+			ResourceType: resource.AssetType,
+		})
+	}
+
+	// The primary and IPv6 ranges are unnamed, secondary ranges carry their range name
+	for _, field := range []string{"ipCidrRange", "internalIpv6Prefix"} {
+		if addr := rangeAddress(resource, subnetFields[field].GetStringValue()); addr != nil {
+			addresses = append(addresses, addr)
+		}
+	}
+
+	for _, secondaryRange := range subnetFields["secondaryIpRanges"].GetListValue().GetValues() {
+		rangeFields := secondaryRange.GetStructValue().GetFields()
+
+		addr := rangeAddress(resource, rangeFields["ipCidrRange"].GetStringValue())
+		if addr == nil {
+			continue
+		}
+
+		addr.RangeName = rangeFields["rangeName"].GetStringValue()
+		addresses = append(addresses, addr)
+	}
+
+	// Everything but the external IPv6 range is only reachable from inside the VPC network, even when it uses public IPs
+	for _, addr := range addresses {
+		setInternal(addr)
+	}
+
+	if addr := rangeAddress(resource, subnetFields["externalIpv6Prefix"].GetStringValue()); addr != nil {
+		addresses = append(addresses, addr)
+	}
+
+	network := normalizeNetwork(subnetFields["network"].GetStringValue())
+	for _, addr := range addresses {
+		addr.Network = network
+	}
+
+	return addresses
+}
+
+// getPeeredNetworks returns the full resource names of the networks a VPC network is peered with
+func getPeeredNetworks(resource *assetpb.ResourceSearchResult) []string {
+	networkResources := resource.GetVersionedResources()
+	if len(networkResources) == 0 {
+		return nil
+	}
+
+	networkResource := networkResources[0]
+	if networkResource == nil {
+		return nil
+	}
+
+	networkResourceValues := networkResource.GetResource()
+	if networkResourceValues == nil {
+		return nil
+	}
+
+	networks := []string{}
+
+	for _, peering := range networkResourceValues.GetFields()["peerings"].GetListValue().GetValues() {
+		network := peering.GetStructValue().GetFields()["network"].GetStringValue()
+		if network != "" {
+			networks = append(networks, normalizeNetwork(network))
+		}
+	}
+
+	return networks
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/netip"
 	"os"
 	"slices"
 	"testing"
@@ -101,6 +102,7 @@ func TestGetAssets(t *testing.T) {
 	testcases := []struct {
 		name              string
 		assetTypes        []string
+		includeRanges     bool
 		expectedAddresses []*gcp.Address
 	}{
 		{
@@ -108,16 +110,251 @@ func TestGetAssets(t *testing.T) {
 			assetTypes: []string{"compute.googleapis.com/Instance"},
 			expectedAddresses: []*gcp.Address{
 				{
-					Address:      "34.83.128.26",
-					AddressType:  "public",
-					ResourceType: "compute.googleapis.com/Instance",
-					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm",
+					Address:          "34.83.128.26",
+					AddressType:      "public",
+					IPVersion:        "ipv4",
+					Classification:   "global-unicast",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AccessConfig:     "external-nat",
+					NetworkTier:      "PREMIUM",
 				},
 				{
-					Address:      "10.0.3.2",
-					AddressType:  "private",
-					ResourceType: "compute.googleapis.com/Instance",
-					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm",
+					Address:          "10.0.3.2",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+				},
+				{
+					Address:          "34.83.128.44",
+					AddressType:      "public",
+					IPVersion:        "ipv4",
+					Classification:   "global-unicast",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AccessConfig:     "external-nat",
+					NetworkTier:      "PREMIUM",
+					AliasIPRanges:    []string{"10.10.0.16/28"},
+				},
+				{
+					Address:          "10.0.3.3",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AliasIPRanges:    []string{"10.10.0.16/28"},
+				},
+				{
+					Address:          "2600:1900:4040:2b1::",
+					AddressType:      "public",
+					IPVersion:        "ipv6",
+					Classification:   "global-unicast",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AccessConfig:     "external-ipv6",
+					NetworkTier:      "PREMIUM",
+					AliasIPRanges:    []string{"10.10.0.16/28"},
+				},
+				{
+					Address:          "10.138.0.5",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic1",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+				},
+				{
+					Address:          "fd20:9c1:4f3a::1:0:0",
+					AddressType:      "private",
+					IPVersion:        "ipv6",
+					Classification:   "ula",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic1",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+				},
+				{
+					Address:        "34.83.128.57",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceType:   "compute.googleapis.com/Instance",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-legacy",
+				},
+				{
+					Address:        "10.0.3.4",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceType:   "compute.googleapis.com/Instance",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-legacy",
+				},
+				{
+					Address:          "10.0.3.2",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-overlap-vm",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/ip-list-test-overlap-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-overlap-subnet",
+				},
+			},
+		},
+		{
+			name:          "compute_instances_with_ranges",
+			assetTypes:    []string{"compute.googleapis.com/Instance"},
+			includeRanges: true,
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:          "34.83.128.26",
+					AddressType:      "public",
+					IPVersion:        "ipv4",
+					Classification:   "global-unicast",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AccessConfig:     "external-nat",
+					NetworkTier:      "PREMIUM",
+				},
+				{
+					Address:          "10.0.3.2",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+				},
+				{
+					Address:          "34.83.128.44",
+					AddressType:      "public",
+					IPVersion:        "ipv4",
+					Classification:   "global-unicast",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AccessConfig:     "external-nat",
+					NetworkTier:      "PREMIUM",
+					AliasIPRanges:    []string{"10.10.0.16/28"},
+				},
+				{
+					Address:          "10.0.3.3",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AliasIPRanges:    []string{"10.10.0.16/28"},
+				},
+				{
+					Address:          "2600:1900:4040:2b1::",
+					AddressType:      "public",
+					IPVersion:        "ipv6",
+					Classification:   "global-unicast",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AccessConfig:     "external-ipv6",
+					NetworkTier:      "PREMIUM",
+					AliasIPRanges:    []string{"10.10.0.16/28"},
+				},
+				{
+					Address:          "10.138.0.5",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic1",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+				},
+				{
+					Address:          "fd20:9c1:4f3a::1:0:0",
+					AddressType:      "private",
+					IPVersion:        "ipv6",
+					Classification:   "ula",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic1",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+				},
+				{
+					Address:        "34.83.128.57",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceType:   "compute.googleapis.com/Instance",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-legacy",
+				},
+				{
+					Address:        "10.0.3.4",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceType:   "compute.googleapis.com/Instance",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-legacy",
+				},
+				{
+					Address:          "10.10.0.16",
+					Prefix:           "10.10.0.16/28",
+					RangeName:        "backend-subnet-aliases",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-vm-ipv6",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					AliasIPRanges:    []string{"10.10.0.16/28"},
+				},
+				{
+					Address:          "10.0.3.2",
+					AddressType:      "private",
+					IPVersion:        "ipv4",
+					Classification:   "rfc1918",
+					ResourceType:     "compute.googleapis.com/Instance",
+					ResourceName:     "//compute.googleapis.com/projects/fuzzy-pickles-428115/zones/us-west1-a/instances/ip-list-test-overlap-vm",
+					NetworkInterface: "nic0",
+					Network:          "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/ip-list-test-overlap-network",
+					Subnetwork:       "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-overlap-subnet",
 				},
 			},
 		},
@@ -126,16 +363,21 @@ func TestGetAssets(t *testing.T) {
 			assetTypes: []string{"sqladmin.googleapis.com/Instance"},
 			expectedAddresses: []*gcp.Address{
 				{
-					Address:      "35.247.31.30",
-					AddressType:  "public",
-					ResourceName: "//cloudsql.googleapis.com/projects/fuzzy-pickles-428115/instances/ip-list-test-db",
-					ResourceType: "sqladmin.googleapis.com/Instance",
+					Address:        "35.247.31.30",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//cloudsql.googleapis.com/projects/fuzzy-pickles-428115/instances/ip-list-test-db",
+					ResourceType:   "sqladmin.googleapis.com/Instance",
 				},
 				{
-					Address:      "10.252.0.3",
-					AddressType:  "private",
-					ResourceName: "//cloudsql.googleapis.com/projects/fuzzy-pickles-428115/instances/ip-list-test-db",
-					ResourceType: "sqladmin.googleapis.com/Instance",
+					Address:        "10.252.0.3",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//cloudsql.googleapis.com/projects/fuzzy-pickles-428115/instances/ip-list-test-db",
+					ResourceType:   "sqladmin.googleapis.com/Instance",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
 				},
 			},
 		},
@@ -144,22 +386,73 @@ func TestGetAssets(t *testing.T) {
 			assetTypes: []string{"compute.googleapis.com/ForwardingRule"},
 			expectedAddresses: []*gcp.Address{
 				{
-					Address:      "34.54.244.120",
-					AddressType:  "public",
-					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-external-static",
-					ResourceType: "compute.googleapis.com/ForwardingRule",
+					Address:        "34.54.244.120",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-external-static",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+				},
+				{
+					Address:        "34.54.243.87",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-external",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+				},
+				{
+					Address:        "10.0.2.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-internal",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
 				},
 				{
-					Address:      "34.54.243.87",
-					AddressType:  "public",
-					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-external",
-					ResourceType: "compute.googleapis.com/ForwardingRule",
+					Address:        "34.19.90.10",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-esp",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-udp500", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-udp4500", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+					},
 				},
 				{
-					Address:      "10.0.2.2",
-					AddressType:  "private",
-					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-internal",
-					ResourceType: "compute.googleapis.com/ForwardingRule",
+					Address:        "34.83.200.15",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/af1a0b7c29f1e4d8e9f650d6b3d5b1c1",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
+				},
+				{
+					Address:        "10.138.0.40",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/k8s2-tcp-4f8s2kq1-default-ip-list-test-internal-se-x7c2m9ad",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "2600:1900:4040:2b1:8000::",
+					AddressType:    "public",
+					IPVersion:      "ipv6",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-forwarding-rule-ipv6",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
 				},
 			},
 		},
@@ -168,28 +461,64 @@ func TestGetAssets(t *testing.T) {
 			assetTypes: []string{"container.googleapis.com/Cluster"},
 			expectedAddresses: []*gcp.Address{
 				{
-					Address:      "34.105.114.31",
-					AddressType:  "public",
-					ResourceName: "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
-					ResourceType: "container.googleapis.com/Cluster",
+					Address:        "34.105.114.31",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					ResourceType:   "container.googleapis.com/Cluster",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
 				},
 				{
-					Address:      "10.138.0.2",
-					AddressType:  "private",
-					ResourceName: "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
-					ResourceType: "container.googleapis.com/Cluster",
+					Address:        "10.138.0.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					ResourceType:   "container.googleapis.com/Cluster",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
 				},
 			},
 		},
 		{
-			name:       "nat_router",
+			name:       "routers",
 			assetTypes: []string{"compute.googleapis.com/Router"},
 			expectedAddresses: []*gcp.Address{
 				{
-					Address:      "34.19.80.22",
-					AddressType:  "public",
-					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-router",
+					Address:        "34.19.80.22",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-router",
+					ResourceType:   "compute.googleapis.com/Router",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/addresses/ip-list-test-nat", ResourceType: "compute.googleapis.com/Address", Relationship: "reserved-by"},
+					},
+				},
+				{
+					Address:        "169.254.10.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "link-local",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-interconnect-router",
+					ResourceType:   "compute.googleapis.com/Router",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "169.254.10.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "link-local",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-interconnect-router",
+					ResourceType:   "compute.googleapis.com/Router",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					// The NAT address is in a project outside of the scope
+					AddressType:  "unresolved",
+					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-external-nat-router",
 					ResourceType: "compute.googleapis.com/Router",
+					Reference:    "https://www.googleapis.com/compute/v1/projects/ip-list-shared-host/regions/us-west1/addresses/ip-list-shared-nat",
 				},
 			},
 		},
@@ -198,16 +527,819 @@ func TestGetAssets(t *testing.T) {
 			assetTypes: []string{"compute.googleapis.com/Address"},
 			expectedAddresses: []*gcp.Address{
 				{
-					Address:      "34.19.80.22",
-					AddressType:  "public",
-					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/addresses/ip-list-test-nat",
-					ResourceType: "compute.googleapis.com/Address",
+					Address:        "34.19.80.22",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/addresses/ip-list-test-nat",
+					ResourceType:   "compute.googleapis.com/Address",
+					AddressScope:   "regional",
+					NetworkTier:    "PREMIUM",
+				},
+				{
+					Address:        "34.19.90.10",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/addresses/ip-list-test-classic-vpn",
+					ResourceType:   "compute.googleapis.com/Address",
+					AddressScope:   "regional",
+					NetworkTier:    "PREMIUM",
+				},
+				{
+					Address:        "11.0.0.10",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "privately-used-public",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/addresses/ip-list-test-pupi-address",
+					ResourceType:   "compute.googleapis.com/Address",
+					AddressScope:   "regional",
+					// The network is set from the subnetwork, which is fetched under the hood
+					Network:    "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Subnetwork: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-pupi-subnet",
+				},
+			},
+		},
+		{
+			name:       "redis_instances",
+			assetTypes: []string{"redis.googleapis.com/Instance"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "10.201.0.3",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//redis.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/instances/ip-list-test-redis",
+					ResourceType:   "redis.googleapis.com/Instance",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "10.201.0.4",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//redis.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/instances/ip-list-test-redis",
+					ResourceType:   "redis.googleapis.com/Instance",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					// Redis endpoints are internal even when the reserved range is PUPI space
+					Address:        "12.0.0.3",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "privately-used-public",
+					ResourceName:   "//redis.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/instances/ip-list-test-pupi-redis",
+					ResourceType:   "redis.googleapis.com/Instance",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+			},
+		},
+		{
+			name:       "filestore_instances",
+			assetTypes: []string{"file.googleapis.com/Instance"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "10.202.0.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//file.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1-a/instances/ip-list-test-filestore",
+					ResourceType:   "file.googleapis.com/Instance",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+			},
+		},
+		{
+			name:       "alloydb_instances",
+			assetTypes: []string{"alloydb.googleapis.com/Instance"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "10.252.1.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//alloydb.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-alloydb/instances/ip-list-test-alloydb-primary",
+					ResourceType:   "alloydb.googleapis.com/Instance",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "34.168.12.39",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//alloydb.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-alloydb/instances/ip-list-test-alloydb-primary",
+					ResourceType:   "alloydb.googleapis.com/Instance",
+				},
+				{
+					Address:        "34.168.12.40",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//alloydb.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-alloydb/instances/ip-list-test-alloydb-primary",
+					ResourceType:   "alloydb.googleapis.com/Instance",
+				},
+				{
+					Address:        "10.252.1.5",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//alloydb.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-alloydb/instances/ip-list-test-alloydb-read-pool",
+					ResourceType:   "alloydb.googleapis.com/Instance",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+			},
+		},
+		{
+			name:       "ha_vpn_gateways",
+			assetTypes: []string{"compute.googleapis.com/VpnGateway"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "35.242.40.17",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/vpnGateways/ip-list-test-ha-vpn",
+					ResourceType:   "compute.googleapis.com/VpnGateway",
+				},
+				{
+					Address:        "35.220.40.221",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/vpnGateways/ip-list-test-ha-vpn",
+					ResourceType:   "compute.googleapis.com/VpnGateway",
+				},
+			},
+		},
+		{
+			name:       "classic_vpn_gateways",
+			assetTypes: []string{"compute.googleapis.com/TargetVpnGateway"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "34.19.90.10",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/targetVpnGateways/ip-list-test-classic-vpn",
+					ResourceType:   "compute.googleapis.com/TargetVpnGateway",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-esp", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-udp500", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-udp4500", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+					},
+				},
+			},
+		},
+		{
+			name:       "classic_vpn_gateways_with_forwarding_rules",
+			assetTypes: []string{"compute.googleapis.com/TargetVpnGateway", "compute.googleapis.com/ForwardingRule"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "34.54.244.120",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-external-static",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+				},
+				{
+					Address:        "34.54.243.87",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-external",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+				},
+				{
+					Address:        "10.0.2.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-internal",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "34.19.90.10",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/targetVpnGateways/ip-list-test-classic-vpn",
+					ResourceType:   "compute.googleapis.com/TargetVpnGateway",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-esp", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-udp500", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-udp4500", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+					},
+				},
+				{
+					Address:        "34.83.200.15",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/af1a0b7c29f1e4d8e9f650d6b3d5b1c1",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
+				},
+				{
+					Address:        "10.138.0.40",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/k8s2-tcp-4f8s2kq1-default-ip-list-test-internal-se-x7c2m9ad",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "2600:1900:4040:2b1:8000::",
+					AddressType:    "public",
+					IPVersion:      "ipv6",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-forwarding-rule-ipv6",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
+				},
+			},
+		},
+		{
+			name:       "interconnect_attachments",
+			assetTypes: []string{"compute.googleapis.com/InterconnectAttachment"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "169.254.10.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "link-local",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/interconnectAttachments/ip-list-test-attachment",
+					ResourceType:   "compute.googleapis.com/InterconnectAttachment",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-interconnect-router", ResourceType: "compute.googleapis.com/Router", Relationship: "used-by"},
+					},
+				},
+				{
+					Address:        "169.254.10.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "link-local",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/interconnectAttachments/ip-list-test-attachment",
+					ResourceType:   "compute.googleapis.com/InterconnectAttachment",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-interconnect-router", ResourceType: "compute.googleapis.com/Router", Relationship: "used-by"},
+					},
+				},
+			},
+		},
+		{
+			// The attachment's addresses are the router's BGP interface and peer addresses, the network comes from the router
+			name:       "interconnect_attachments_with_routers",
+			assetTypes: []string{"compute.googleapis.com/InterconnectAttachment", "compute.googleapis.com/Router"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "34.19.80.22",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-router",
+					ResourceType:   "compute.googleapis.com/Router",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/addresses/ip-list-test-nat", ResourceType: "compute.googleapis.com/Address", Relationship: "reserved-by"},
+					},
+				},
+				{
+					Address:        "169.254.10.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "link-local",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/interconnectAttachments/ip-list-test-attachment",
+					ResourceType:   "compute.googleapis.com/InterconnectAttachment",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-interconnect-router", ResourceType: "compute.googleapis.com/Router", Relationship: "used-by"},
+					},
+				},
+				{
+					Address:        "169.254.10.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "link-local",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/interconnectAttachments/ip-list-test-attachment",
+					ResourceType:   "compute.googleapis.com/InterconnectAttachment",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-interconnect-router", ResourceType: "compute.googleapis.com/Router", Relationship: "used-by"},
+					},
+				},
+				{
+					// The NAT address is in a project outside of the scope
+					AddressType:  "unresolved",
+					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/routers/ip-list-test-external-nat-router",
+					ResourceType: "compute.googleapis.com/Router",
+					Reference:    "https://www.googleapis.com/compute/v1/projects/ip-list-shared-host/regions/us-west1/addresses/ip-list-shared-nat",
+				},
+			},
+		},
+		{
+			name:       "global_addresses",
+			assetTypes: []string{"compute.googleapis.com/GlobalAddress"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "34.54.244.120",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/addresses/ip-list-test-static-address",
+					ResourceType:   "compute.googleapis.com/GlobalAddress",
+					AddressScope:   "global",
+					NetworkTier:    "PREMIUM",
+				},
+			},
+		},
+		{
+			name:          "global_addresses_with_ranges",
+			assetTypes:    []string{"compute.googleapis.com/GlobalAddress"},
+			includeRanges: true,
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "34.54.244.120",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/addresses/ip-list-test-static-address",
+					ResourceType:   "compute.googleapis.com/GlobalAddress",
+					AddressScope:   "global",
+					NetworkTier:    "PREMIUM",
+				},
+				{
+					Address:        "10.252.0.0",
+					Prefix:         "10.252.0.0/16",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/addresses/ip-list-test-cloudsql-private",
+					ResourceType:   "compute.googleapis.com/GlobalAddress",
+					AddressScope:   "global",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+			},
+		},
+		{
+			name:       "kubernetes_services",
+			assetTypes: []string{"k8s.io/Service"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:             "34.118.230.12",
+					AddressType:         "private",
+					IPVersion:           "ipv4",
+					Classification:      "privately-used-public",
+					ResourceName:        "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/default/services/ip-list-test-service",
+					ResourceType:        "k8s.io/Service",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "default",
 				},
 				{
-					Address:      "34.54.244.120",
-					AddressType:  "public",
-					ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/addresses/ip-list-test-static-address",
-					ResourceType: "compute.googleapis.com/Address",
+					Address:             "34.83.200.15",
+					AddressType:         "public",
+					IPVersion:           "ipv4",
+					Classification:      "global-unicast",
+					ResourceName:        "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/default/services/ip-list-test-service",
+					ResourceType:        "k8s.io/Service",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "default",
+				},
+				{
+					Address:             "34.118.231.40",
+					AddressType:         "private",
+					IPVersion:           "ipv4",
+					Classification:      "privately-used-public",
+					ResourceName:        "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/default/services/ip-list-test-internal-service",
+					ResourceType:        "k8s.io/Service",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "default",
+				},
+				{
+					Address:        "10.138.0.40",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/default/services/ip-list-test-internal-service",
+					ResourceType:   "k8s.io/Service",
+					// The network of the internal load balancer IP comes from the GKE cluster
+					Network:             "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "default",
+				},
+			},
+		},
+		{
+			name:       "kubernetes_services_with_forwarding_rules",
+			assetTypes: []string{"k8s.io/Service", "compute.googleapis.com/ForwardingRule"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "34.54.244.120",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-external-static",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+				},
+				{
+					Address:        "34.54.243.87",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-external",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+				},
+				{
+					Address:        "10.0.2.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/forwardingRules/ip-list-test-forwarding-rule-internal",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "global",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "34.19.90.10",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-esp",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-udp500", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-classic-vpn-udp4500", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+					},
+				},
+				{
+					Address:             "34.118.230.12",
+					AddressType:         "private",
+					IPVersion:           "ipv4",
+					Classification:      "privately-used-public",
+					ResourceName:        "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/default/services/ip-list-test-service",
+					ResourceType:        "k8s.io/Service",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "default",
+				},
+				{
+					Address:             "34.83.200.15",
+					AddressType:         "public",
+					IPVersion:           "ipv4",
+					Classification:      "global-unicast",
+					ResourceName:        "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/default/services/ip-list-test-service",
+					ResourceType:        "k8s.io/Service",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "default",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/af1a0b7c29f1e4d8e9f650d6b3d5b1c1", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+					},
+				},
+				{
+					Address:             "34.118.231.40",
+					AddressType:         "private",
+					IPVersion:           "ipv4",
+					Classification:      "privately-used-public",
+					ResourceName:        "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/default/services/ip-list-test-internal-service",
+					ResourceType:        "k8s.io/Service",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "default",
+				},
+				{
+					Address:        "10.138.0.40",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/default/services/ip-list-test-internal-service",
+					ResourceType:   "k8s.io/Service",
+					// The network of the internal load balancer IP comes from the GKE cluster
+					Network:             "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "default",
+					Related: []gcp.RelatedResource{
+						{ResourceName: "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/k8s2-tcp-4f8s2kq1-default-ip-list-test-internal-se-x7c2m9ad", ResourceType: "compute.googleapis.com/ForwardingRule", Relationship: "used-by"},
+					},
+				},
+				{
+					Address:        "2600:1900:4040:2b1:8000::",
+					AddressType:    "public",
+					IPVersion:      "ipv6",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/forwardingRules/ip-list-test-forwarding-rule-ipv6",
+					ResourceType:   "compute.googleapis.com/ForwardingRule",
+					AddressScope:   "regional",
+				},
+			},
+		},
+		{
+			name:       "kubernetes_ingresses",
+			assetTypes: []string{"networking.k8s.io/Ingress"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:             "34.120.45.9",
+					AddressType:         "public",
+					IPVersion:           "ipv4",
+					Classification:      "global-unicast",
+					ResourceName:        "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/web/ingresses/ip-list-test-ingress",
+					ResourceType:        "networking.k8s.io/Ingress",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "web",
+				},
+			},
+		},
+		{
+			name:       "kubernetes_gateways",
+			assetTypes: []string{"gateway.networking.k8s.io/Gateway"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:             "34.117.88.201",
+					AddressType:         "public",
+					IPVersion:           "ipv4",
+					Classification:      "global-unicast",
+					ResourceName:        "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster/k8s/namespaces/web/gateways/ip-list-test-gateway",
+					ResourceType:        "gateway.networking.k8s.io/Gateway",
+					KubernetesCluster:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					KubernetesNamespace: "web",
+				},
+			},
+		},
+		{
+			name:          "gke_cluster_with_ranges",
+			assetTypes:    []string{"container.googleapis.com/Cluster"},
+			includeRanges: true,
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "34.105.114.31",
+					AddressType:    "public",
+					IPVersion:      "ipv4",
+					Classification: "global-unicast",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					ResourceType:   "container.googleapis.com/Cluster",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "10.138.0.2",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					ResourceType:   "container.googleapis.com/Cluster",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "10.84.0.0",
+					Prefix:         "10.84.0.0/14",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					ResourceType:   "container.googleapis.com/Cluster",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "34.118.224.0",
+					Prefix:         "34.118.224.0/20",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "privately-used-public",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					ResourceType:   "container.googleapis.com/Cluster",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "172.16.0.0",
+					Prefix:         "172.16.0.0/28",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					ResourceType:   "container.googleapis.com/Cluster",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "10.96.0.0",
+					Prefix:         "10.96.0.0/16",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//container.googleapis.com/projects/fuzzy-pickles-428115/locations/us-west1/clusters/ip-list-test-cluster",
+					ResourceType:   "container.googleapis.com/Cluster",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+			},
+		},
+		{
+			name:       "subnetworks",
+			assetTypes: []string{"compute.googleapis.com/Subnetwork"},
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "10.0.3.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "10.0.2.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/lb-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "10.138.0.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "10.0.0.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-overlap-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/ip-list-test-overlap-network",
+				},
+				{
+					Address:        "11.0.0.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "privately-used-public",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-pupi-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+			},
+		},
+		{
+			name:          "subnetworks_with_ranges",
+			assetTypes:    []string{"compute.googleapis.com/Subnetwork"},
+			includeRanges: true,
+			expectedAddresses: []*gcp.Address{
+				{
+					Address:        "10.0.3.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "10.0.2.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/lb-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "10.138.0.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "10.0.3.0",
+					Prefix:         "10.0.3.0/24",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "2600:1900:4040:2b1::",
+					Prefix:         "2600:1900:4040:2b1::/64",
+					AddressType:    "public",
+					IPVersion:      "ipv6",
+					Classification: "global-unicast",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "10.10.0.0",
+					Prefix:         "10.10.0.0/20",
+					RangeName:      "backend-subnet-aliases",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/backend-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "10.0.2.0",
+					Prefix:         "10.0.2.0/24",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/lb-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "10.138.0.0",
+					Prefix:         "10.138.0.0/20",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "fd20:9c1:4f3a::",
+					Prefix:         "fd20:9c1:4f3a::/64",
+					AddressType:    "private",
+					IPVersion:      "ipv6",
+					Classification: "ula",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "10.84.0.0",
+					Prefix:         "10.84.0.0/14",
+					RangeName:      "gke-ip-list-test-cluster-pods-b4fa9d0e",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/default",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/default",
+				},
+				{
+					Address:        "10.0.0.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-overlap-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/ip-list-test-overlap-network",
+				},
+				{
+					Address:        "10.0.0.0",
+					Prefix:         "10.0.0.0/16",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-overlap-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/ip-list-test-overlap-network",
+				},
+				{
+					Address:        "10.86.0.0",
+					Prefix:         "10.86.0.0/16",
+					RangeName:      "ip-list-test-overlap-pods",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "rfc1918",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-overlap-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/ip-list-test-overlap-network",
+				},
+				{
+					Address:        "11.0.0.1",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "privately-used-public",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-pupi-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
+				},
+				{
+					Address:        "11.0.0.0",
+					Prefix:         "11.0.0.0/24",
+					AddressType:    "private",
+					IPVersion:      "ipv4",
+					Classification: "privately-used-public",
+					ResourceName:   "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/subnetworks/ip-list-test-pupi-subnet",
+					ResourceType:   "compute.googleapis.com/Subnetwork",
+					Network:        "//compute.googleapis.com/projects/fuzzy-pickles-428115/global/networks/public-ip-list-network",
 				},
 			},
 		},
@@ -221,10 +1353,11 @@ func TestGetAssets(t *testing.T) {
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			addr, err := gcp.GetAddressesFromAssetInventory(
+			addr, err := gcp.GetAddressesFromAssetInventoryWithOptions(
 				context.Background(),
 				scope,
 				tc.assetTypes,
+				gcp.Options{IncludeRanges: tc.includeRanges},
 
 				// These are necessary to get the Google Cloud SDK to use the fake grpc server
 				option.WithEndpoint(server.Addr().String()),
@@ -235,7 +1368,221 @@ func TestGetAssets(t *testing.T) {
 				t.Fatalf("error getting addresses from asset inventory: %s", err)
 			}
 
+			// Resource metadata is covered by TestGetAssetsResourceMetadata
+			for _, a := range addr {
+				clearResourceMetadata(a)
+			}
+
 			require.ElementsMatch(t, tc.expectedAddresses, addr)
 		})
 	}
 }
+
+func TestGetAllAddressesKubernetesGateways(t *testing.T) {
+	server, err := setupTestServer()
+	if err != nil {
+		t.Fatalf("error setting up test server: %s", err)
+	}
+	defer server.Close() //nolint:errcheck
+
+	testcases := []struct {
+		name            string
+		options         gcp.Options
+		expectedGateway bool
+	}{
+		{
+			// Gateway API gateways aren't searched by default since Cloud Asset Inventory may reject the asset type
+			name:            "default",
+			options:         gcp.Options{},
+			expectedGateway: false,
+		},
+		{
+			name:            "included",
+			options:         gcp.Options{IncludeKubernetesGateways: true},
+			expectedGateway: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, err := gcp.GetAllAddressesFromAssetInventoryWithOptions(
+				context.Background(),
+				scope,
+				tc.options,
+
+				// These are necessary to get the Google Cloud SDK to use the fake grpc server
+				option.WithEndpoint(server.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			)
+			if err != nil {
+				t.Fatalf("error getting addresses from asset inventory: %s", err)
+			}
+
+			hasGateway := slices.ContainsFunc(addr, func(a *gcp.Address) bool {
+				return a.ResourceType == gcp.AssetTypeKubernetesGateway
+			})
+			require.Equal(t, tc.expectedGateway, hasGateway)
+		})
+	}
+}
+
+func TestGetAssetsResourceMetadata(t *testing.T) {
+	server, err := setupTestServer()
+	if err != nil {
+		t.Fatalf("error setting up test server: %s", err)
+	}
+	defer server.Close() //nolint:errcheck
+
+	addr, err := gcp.GetAddressesFromAssetInventory(
+		context.Background(),
+		scope,
+		[]string{"compute.googleapis.com/Instance", "compute.googleapis.com/Router"},
+
+		// These are necessary to get the Google Cloud SDK to use the fake grpc server
+		option.WithEndpoint(server.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("error getting addresses from asset inventory: %s", err)
+	}
+
+	addresses := map[string]*gcp.Address{}
+	for _, a := range addr {
+		addresses[a.Address] = a
+	}
+
+	require.Contains(t, addresses, "34.83.128.26")
+	require.Equal(t, "projects/828107101350", addresses["34.83.128.26"].Project)
+	require.Equal(t, "us-west1-a", addresses["34.83.128.26"].Location)
+	require.Equal(t, "ip-list-test-vm", addresses["34.83.128.26"].DisplayName)
+	require.Equal(t, map[string]string{"env": "test", "team": "platform"}, addresses["34.83.128.26"].Labels)
+	require.Equal(t, "2024-07-01T16:16:26Z", addresses["34.83.128.26"].CreateTime)
+	require.Equal(t, "RUNNING", addresses["34.83.128.26"].State)
+	require.Equal(t, "//cloudresourcemanager.googleapis.com/projects/fuzzy-pickles-428115", addresses["34.83.128.26"].ParentFullResourceName)
+
+	// Resolved references keep the metadata of the referencing resource
+	require.Contains(t, addresses, "34.19.80.22")
+	require.Equal(t, "ip-list-test-router", addresses["34.19.80.22"].DisplayName)
+	require.Equal(t, "us-west1", addresses["34.19.80.22"].Location)
+	require.Nil(t, addresses["34.19.80.22"].Labels)
+}
+
+func clearResourceMetadata(a *gcp.Address) {
+	a.Project = ""
+	a.Location = ""
+	a.DisplayName = ""
+	a.Labels = nil
+	a.CreateTime = ""
+	a.State = ""
+	a.ParentFullResourceName = ""
+}
+
+func TestGetAssetsWithPrivateRanges(t *testing.T) {
+	testcases := []struct {
+		name                   string
+		options                gcp.Options
+		expectedClassification map[string]string
+	}{
+		{
+			// Internal static addresses are privately used even without any private ranges
+			name:    "internal_addresses",
+			options: gcp.Options{},
+			expectedClassification: map[string]string{
+				"34.19.80.22": gcp.ClassificationGlobalUnicast,
+				"34.19.90.10": gcp.ClassificationGlobalUnicast,
+				"11.0.0.10":   gcp.ClassificationPrivatelyUsedPublic,
+			},
+		},
+		{
+			name:    "configured_private_ranges",
+			options: gcp.Options{PrivateRanges: []netip.Prefix{netip.MustParsePrefix("34.19.90.0/24")}},
+			expectedClassification: map[string]string{
+				"34.19.80.22": gcp.ClassificationGlobalUnicast,
+				"34.19.90.10": gcp.ClassificationPrivatelyUsedPublic,
+				"11.0.0.10":   gcp.ClassificationPrivatelyUsedPublic,
+			},
+		},
+		{
+			name:    "detected_private_ranges",
+			options: gcp.Options{DetectPrivatelyUsedPublicRanges: true},
+			expectedClassification: map[string]string{
+				"34.19.80.22": gcp.ClassificationGlobalUnicast,
+				"34.19.90.10": gcp.ClassificationGlobalUnicast,
+				"11.0.0.10":   gcp.ClassificationPrivatelyUsedPublic,
+			},
+		},
+	}
+
+	server, err := setupTestServer()
+	if err != nil {
+		t.Fatalf("error setting up test server: %s", err)
+	}
+	defer server.Close() //nolint:errcheck
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, err := gcp.GetAddressesFromAssetInventoryWithOptions(
+				context.Background(),
+				scope,
+				[]string{"compute.googleapis.com/Address"},
+				tc.options,
+
+				// These are necessary to get the Google Cloud SDK to use the fake grpc server
+				option.WithEndpoint(server.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			)
+			if err != nil {
+				t.Fatalf("error getting addresses from asset inventory: %s", err)
+			}
+
+			classification := map[string]string{}
+			for _, a := range addr {
+				// Subnets fetched to detect privately used public ranges shouldn't be returned
+				require.Equal(t, "compute.googleapis.com/Address", a.ResourceType)
+				require.Equal(t, a.Classification == gcp.ClassificationGlobalUnicast, a.AddressType == gcp.AddressTypePublic)
+
+				classification[a.Address] = a.Classification
+			}
+
+			require.Equal(t, tc.expectedClassification, classification)
+		})
+	}
+}
+
+func TestGetAssetsWithReservedAddresses(t *testing.T) {
+	server, err := setupTestServer()
+	if err != nil {
+		t.Fatalf("error setting up test server: %s", err)
+	}
+	defer server.Close() //nolint:errcheck
+
+	addr, err := gcp.GetAddressesFromAssetInventoryWithOptions(
+		context.Background(),
+		scope,
+		[]string{"compute.googleapis.com/Address", "compute.googleapis.com/GlobalAddress"},
+		gcp.Options{IncludeReserved: true},
+
+		// These are necessary to get the Google Cloud SDK to use the fake grpc server
+		option.WithEndpoint(server.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("error getting addresses from asset inventory: %s", err)
+	}
+
+	reserved := gcp.FilterReservedAddresses(addr)
+
+	// Private services access ranges are also RESERVED but they are allocated to the service producer network
+	require.Len(t, reserved, 1)
+	require.Equal(t, "34.19.68.170", reserved[0].Address)
+	require.Equal(t, "//compute.googleapis.com/projects/fuzzy-pickles-428115/regions/us-west1/addresses/ip-list-test-public-not-used", reserved[0].ResourceName)
+	require.Equal(t, "us-west1", reserved[0].Location)
+	require.Equal(t, "PREMIUM", reserved[0].NetworkTier)
+	require.Equal(t, "2024-07-01T16:16:37Z", reserved[0].CreateTime)
+	require.Equal(t, "RESERVED", reserved[0].State)
+	require.NotEmpty(t, reserved[0].Project)
+}
@@ -0,0 +1,234 @@
+package gcp
+
+import (
+	"cmp"
+	"encoding/binary"
+	"net/netip"
+	"slices"
+)
+
+// maxFreeBlocks is the number of free blocks reported for each subnet range
+const maxFreeBlocks = 3
+
+// SubnetUtilization describes how much of a subnet range is allocated to the addresses and ranges inside of it
+type SubnetUtilization struct {
+	ResourceName string `json:"resource_name"`
+	Prefix       string `json:"prefix"`
+	RangeName    string `json:"range_name,omitempty"`
+
+	// Allocated and Capacity exclude the addresses Google reserves in every primary subnet range
+	Allocated   uint64  `json:"allocated"`
+	Capacity    uint64  `json:"capacity"`
+	Utilization float64 `json:"utilization_percent"`
+
+	// LargestFreeBlocks holds the largest unallocated CIDR blocks in the range, largest first
+	LargestFreeBlocks []string `json:"largest_free_blocks"`
+}
+
+// utilizationAllocation is an address or range that can be allocated from a subnet range
+type utilizationAllocation struct {
+	prefix     netip.Prefix
+	subnetwork string
+}
+
+// GetSubnetUtilization joins the given addresses to the IPv4 subnet ranges (primary and secondary) containing them and
+// reports the utilization of each range. The addresses must include the subnet ranges themselves
+// (see Options.IncludeRanges). Addresses are only joined to the subnets of their own VPC network (or their own subnet
+// when it is known), so private addresses whose network isn't known are skipped. Results are sorted by utilization,
+// highest first.
+func GetSubnetUtilization(addresses []*Address) []*SubnetUtilization {
+	subnetNetworks := map[string]string{}
+	for _, addr := range addresses {
+		if addr.ResourceType == AssetTypeComputeSubnetwork {
+			subnetNetworks[addr.ResourceName] = addr.Network
+		}
+	}
+
+	// Parse every allocation once and group them by network so each subnet range is only compared with its own network
+	allocationsByNetwork := map[string][]utilizationAllocation{}
+	for _, addr := range addresses {
+		// Skip the subnet ranges and gateways, the gateways are covered by the reserved addresses below
+		if addr.ResourceType == AssetTypeComputeSubnetwork {
+			continue
+		}
+
+		// GKE pod and service ranges span the whole secondary range they use, the per-node alias ranges carved out
+		// of them are what is actually allocated
+		if addr.ResourceType == AssetTypeContainerCluster && addr.IsRange() {
+			continue
+		}
+
+		network := addr.Network
+		if network == "" {
+			network = subnetNetworks[addr.Subnetwork]
+		}
+
+		// The same private range can be reused in other VPC networks so there's no telling which subnet they belong to
+		if network == "" && addr.AddressType == AddressTypePrivate {
+			continue
+		}
+
+		prefix, err := addressPrefix(addr)
+		if err != nil || !prefix.Addr().Is4() {
+			continue
+		}
+
+		allocationsByNetwork[network] = append(allocationsByNetwork[network], utilizationAllocation{
+			prefix:     prefix,
+			subnetwork: addr.Subnetwork,
+		})
+	}
+
+	results := []*SubnetUtilization{}
+
+	for _, subnet := range addresses {
+		if subnet.ResourceType != AssetTypeComputeSubnetwork || !subnet.IsRange() {
+			continue
+		}
+
+		subnetPrefix, err := netip.ParsePrefix(subnet.Prefix)
+		if err != nil || !subnetPrefix.Addr().Is4() {
+			continue
+		}
+
+		// Public addresses are unique so the ones whose network isn't known can still be joined to any subnet
+		candidates := allocationsByNetwork[subnet.Network]
+		if subnet.Network != "" {
+			candidates = append(slices.Clip(candidates), allocationsByNetwork[""]...)
+		}
+
+		allocated := []netip.Prefix{}
+		for _, a := range candidates {
+			if a.subnetwork != "" && a.subnetwork != subnet.ResourceName {
+				continue
+			}
+			if !a.prefix.Overlaps(subnetPrefix) {
+				continue
+			}
+			allocated = append(allocated, a.prefix)
+		}
+
+		size := prefixSize(subnetPrefix)
+		reserved := reservedAddresses(subnet, subnetPrefix)
+		free := freeBlocks(subnetPrefix, append(allocated, reserved...))
+
+		freeCount := uint64(0)
+		for _, block := range free {
+			freeCount += prefixSize(block)
+		}
+
+		capacity := size - uint64(len(reserved))
+		used := capacity - freeCount
+
+		utilization := 0.0
+		if capacity > 0 {
+			utilization = float64(used) / float64(capacity) * 100
+		}
+
+		// Larger blocks have shorter prefixes
+		slices.SortFunc(free, func(a, b netip.Prefix) int {
+			return cmp.Or(
+				cmp.Compare(a.Bits(), b.Bits()),
+				a.Addr().Compare(b.Addr()),
+			)
+		})
+
+		largest := []string{}
+		for _, block := range free[:min(len(free), maxFreeBlocks)] {
+			largest = append(largest, block.String())
+		}
+
+		results = append(results, &SubnetUtilization{
+			ResourceName:      subnet.ResourceName,
+			Prefix:            subnetPrefix.String(),
+			RangeName:         subnet.RangeName,
+			Allocated:         used,
+			Capacity:          capacity,
+			Utilization:       utilization,
+			LargestFreeBlocks: largest,
+		})
+	}
+
+	slices.SortFunc(results, func(a, b *SubnetUtilization) int {
+		return cmp.Or(
+			cmp.Compare(a.Utilization, b.Utilization)*-1,
+			cmp.Compare(a.ResourceName, b.ResourceName),
+			cmp.Compare(a.Prefix, b.Prefix),
+		)
+	})
+
+	return results
+}
+
+// addressPrefix returns the range for range addresses or a single address prefix (i.e. /32) for single addresses
+func addressPrefix(addr *Address) (netip.Prefix, error) {
+	if addr.IsRange() {
+		return netip.ParsePrefix(addr.Prefix)
+	}
+
+	ip, err := netip.ParseAddr(addr.Address)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(ip, ip.BitLen()), nil
+}
+
+// reservedAddresses returns the four addresses Google reserves in every primary IPv4 subnet range: the network address,
+// the default gateway, the second-to-last address and the broadcast address. Secondary ranges have no reserved addresses.
+func reservedAddresses(subnet *Address, prefix netip.Prefix) []netip.Prefix {
+	if subnet.RangeName != "" || prefix.Bits() > 29 {
+		return nil
+	}
+
+	first := ipv4ToUint32(prefix.Addr())
+	last := first + uint32(prefixSize(prefix)) - 1
+
+	reserved := []netip.Prefix{}
+	for _, ip := range []uint32{first, first + 1, last - 1, last} {
+		reserved = append(reserved, netip.PrefixFrom(uint32ToIPv4(ip), 32))
+	}
+
+	return reserved
+}
+
+// freeBlocks returns the largest aligned CIDR blocks within the prefix that do not overlap any of the allocated prefixes
+func freeBlocks(prefix netip.Prefix, allocated []netip.Prefix) []netip.Prefix {
+	overlapping := []netip.Prefix{}
+
+	for _, a := range allocated {
+		if !a.Overlaps(prefix) {
+			continue
+		}
+
+		// Prefixes either contain each other or don't overlap at all so this means the whole block is allocated
+		if a.Bits() <= prefix.Bits() {
+			return nil
+		}
+		overlapping = append(overlapping, a)
+	}
+
+	if len(overlapping) == 0 {
+		return []netip.Prefix{prefix}
+	}
+
+	lower := netip.PrefixFrom(prefix.Addr(), prefix.Bits()+1)
+	upper := netip.PrefixFrom(uint32ToIPv4(ipv4ToUint32(prefix.Addr())|(1<<(31-prefix.Bits()))), prefix.Bits()+1)
+
+	return append(freeBlocks(lower, overlapping), freeBlocks(upper, overlapping)...)
+}
+
+// prefixSize returns the number of addresses in an IPv4 prefix
+func prefixSize(prefix netip.Prefix) uint64 {
+	return 1 << (32 - prefix.Bits())
+}
+
+func ipv4ToUint32(ip netip.Addr) uint32 {
+	b := ip.As4()
+	return binary.BigEndian.Uint32(b[:])
+}
+
+func uint32ToIPv4(ip uint32) netip.Addr {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], ip)
+	return netip.AddrFrom4(b)
+}
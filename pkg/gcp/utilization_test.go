@@ -0,0 +1,250 @@
+package gcp_test
+
+import (
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSubnetUtilization(t *testing.T) {
+	subnet := "//compute.googleapis.com/projects/p/regions/us-west1/subnetworks/backend-subnet"
+	instance := "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/vm"
+
+	testAddresses := []*gcp.Address{
+		{
+			Address:      "10.0.3.1",
+			ResourceName: subnet,
+			ResourceType: gcp.AssetTypeComputeSubnetwork,
+		},
+		{
+			Address:      "10.0.3.0",
+			Prefix:       "10.0.3.0/24",
+			ResourceName: subnet,
+			ResourceType: gcp.AssetTypeComputeSubnetwork,
+		},
+		{
+			Address:      "10.10.0.0",
+			Prefix:       "10.10.0.0/20",
+			RangeName:    "aliases",
+			ResourceName: subnet,
+			ResourceType: gcp.AssetTypeComputeSubnetwork,
+		},
+		{
+			Address:      "10.0.3.2",
+			ResourceName: instance,
+			ResourceType: gcp.AssetTypeComputeInstance,
+		},
+		{
+			Address:      "10.0.3.10",
+			ResourceName: "//compute.googleapis.com/projects/p/regions/us-west1/forwardingRules/rule",
+			ResourceType: gcp.AssetTypeComputeForwardingRule,
+		},
+		{
+			Address:      "10.10.0.0",
+			Prefix:       "10.10.0.0/24",
+			ResourceName: instance,
+			ResourceType: gcp.AssetTypeComputeInstance,
+		},
+		{
+			Address:      "34.83.128.26",
+			ResourceName: instance,
+			ResourceType: gcp.AssetTypeComputeInstance,
+		},
+	}
+
+	utilization := gcp.GetSubnetUtilization(testAddresses)
+
+	require.Equal(t, []*gcp.SubnetUtilization{
+		{
+			ResourceName:      subnet,
+			Prefix:            "10.10.0.0/20",
+			RangeName:         "aliases",
+			Allocated:         256,
+			Capacity:          4096,
+			Utilization:       6.25,
+			LargestFreeBlocks: []string{"10.10.8.0/21", "10.10.4.0/22", "10.10.2.0/23"},
+		},
+		{
+			ResourceName:      subnet,
+			Prefix:            "10.0.3.0/24",
+			Allocated:         2,
+			Capacity:          252,
+			Utilization:       float64(2) / 252 * 100,
+			LargestFreeBlocks: []string{"10.0.3.64/26", "10.0.3.128/26", "10.0.3.32/27"},
+		},
+	}, utilization)
+}
+
+func TestGetSubnetUtilizationGKEPods(t *testing.T) {
+	subnet := "//compute.googleapis.com/projects/p/regions/us-west1/subnetworks/default"
+	cluster := "//container.googleapis.com/projects/p/locations/us-west1/clusters/cluster"
+
+	testAddresses := []*gcp.Address{
+		{
+			Address:      "10.84.0.0",
+			Prefix:       "10.84.0.0/14",
+			RangeName:    "gke-pods",
+			ResourceName: subnet,
+			ResourceType: gcp.AssetTypeComputeSubnetwork,
+		},
+		{
+			// The cluster's pod range is the whole secondary range so it doesn't count towards the allocation
+			Address:      "10.84.0.0",
+			Prefix:       "10.84.0.0/14",
+			ResourceName: cluster,
+			ResourceType: gcp.AssetTypeContainerCluster,
+		},
+		{
+			Address:      "10.84.0.0",
+			Prefix:       "10.84.0.0/24",
+			RangeName:    "gke-pods",
+			ResourceName: "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/gke-node-1",
+			ResourceType: gcp.AssetTypeComputeInstance,
+		},
+		{
+			Address:      "10.84.1.0",
+			Prefix:       "10.84.1.0/24",
+			RangeName:    "gke-pods",
+			ResourceName: "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/gke-node-2",
+			ResourceType: gcp.AssetTypeComputeInstance,
+		},
+	}
+
+	utilization := gcp.GetSubnetUtilization(testAddresses)
+
+	require.Equal(t, []*gcp.SubnetUtilization{
+		{
+			ResourceName:      subnet,
+			Prefix:            "10.84.0.0/14",
+			RangeName:         "gke-pods",
+			Allocated:         512,
+			Capacity:          1 << 18,
+			Utilization:       float64(512) / (1 << 18) * 100,
+			LargestFreeBlocks: []string{"10.86.0.0/15", "10.85.0.0/16", "10.84.128.0/17"},
+		},
+	}, utilization)
+}
+
+func TestGetSubnetUtilizationFull(t *testing.T) {
+	subnet := "//compute.googleapis.com/projects/p/regions/us-west1/subnetworks/default"
+
+	testAddresses := []*gcp.Address{
+		{
+			Address:      "10.84.0.0",
+			Prefix:       "10.84.0.0/23",
+			RangeName:    "gke-pods",
+			ResourceName: subnet,
+			ResourceType: gcp.AssetTypeComputeSubnetwork,
+		},
+		{
+			Address:      "10.84.0.0",
+			Prefix:       "10.84.0.0/24",
+			ResourceName: "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/gke-node-1",
+			ResourceType: gcp.AssetTypeComputeInstance,
+		},
+		{
+			Address:      "10.84.1.0",
+			Prefix:       "10.84.1.0/24",
+			ResourceName: "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/gke-node-2",
+			ResourceType: gcp.AssetTypeComputeInstance,
+		},
+	}
+
+	utilization := gcp.GetSubnetUtilization(testAddresses)
+
+	require.Len(t, utilization, 1)
+	require.Equal(t, float64(100), utilization[0].Utilization)
+	require.Equal(t, uint64(512), utilization[0].Allocated)
+	require.Empty(t, utilization[0].LargestFreeBlocks)
+}
+
+func TestGetSubnetUtilizationOtherNetwork(t *testing.T) {
+	testAddresses := []*gcp.Address{
+		{
+			Address:      "10.0.3.0",
+			Prefix:       "10.0.3.0/24",
+			ResourceName: "//compute.googleapis.com/projects/p/regions/us-west1/subnetworks/backend-subnet",
+			ResourceType: gcp.AssetTypeComputeSubnetwork,
+			Network:      "//compute.googleapis.com/projects/p/global/networks/a",
+		},
+		{
+			Address:      "10.0.3.2",
+			ResourceName: "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/vm-a",
+			ResourceType: gcp.AssetTypeComputeInstance,
+			Network:      "//compute.googleapis.com/projects/p/global/networks/a",
+		},
+		{
+			// Addresses in the same range of another network don't count towards the subnet
+			Address:      "10.0.3.3",
+			ResourceName: "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/vm-b",
+			ResourceType: gcp.AssetTypeComputeInstance,
+			Network:      "//compute.googleapis.com/projects/p/global/networks/b",
+		},
+	}
+
+	utilization := gcp.GetSubnetUtilization(testAddresses)
+
+	require.Len(t, utilization, 1)
+	require.Equal(t, uint64(1), utilization[0].Allocated)
+}
+
+func TestGetSubnetUtilizationReusedRange(t *testing.T) {
+	subnetA := "//compute.googleapis.com/projects/p/regions/us-west1/subnetworks/subnet-a"
+	subnetB := "//compute.googleapis.com/projects/p/regions/us-west1/subnetworks/subnet-b"
+	networkA := "//compute.googleapis.com/projects/p/global/networks/a"
+	networkB := "//compute.googleapis.com/projects/p/global/networks/b"
+
+	testAddresses := []*gcp.Address{
+		{
+			Address:      "10.0.0.0",
+			Prefix:       "10.0.0.0/24",
+			AddressType:  gcp.AddressTypePrivate,
+			ResourceName: subnetA,
+			ResourceType: gcp.AssetTypeComputeSubnetwork,
+			Network:      networkA,
+		},
+		{
+			Address:      "10.0.0.0",
+			Prefix:       "10.0.0.0/16",
+			AddressType:  gcp.AddressTypePrivate,
+			ResourceName: subnetB,
+			ResourceType: gcp.AssetTypeComputeSubnetwork,
+			Network:      networkB,
+		},
+		{
+			Address:      "10.0.0.2",
+			AddressType:  gcp.AddressTypePrivate,
+			ResourceName: "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/vm-a",
+			ResourceType: gcp.AssetTypeComputeInstance,
+			Network:      networkA,
+			Subnetwork:   subnetA,
+		},
+		{
+			// The network is known from the subnetwork
+			Address:      "10.0.0.3",
+			AddressType:  gcp.AddressTypePrivate,
+			ResourceName: "//compute.googleapis.com/projects/p/regions/us-west1/addresses/address-b",
+			ResourceType: gcp.AssetTypeComputeAddress,
+			Subnetwork:   subnetB,
+		},
+		{
+			// Private addresses whose network isn't known could belong to either subnet so they aren't counted
+			Address:      "10.0.0.4",
+			AddressType:  gcp.AddressTypePrivate,
+			ResourceName: "//compute.googleapis.com/projects/p/zones/us-west1-a/instances/legacy-vm",
+			ResourceType: gcp.AssetTypeComputeInstance,
+		},
+	}
+
+	utilization := gcp.GetSubnetUtilization(testAddresses)
+
+	require.Len(t, utilization, 2)
+
+	allocated := map[string]uint64{}
+	for _, u := range utilization {
+		allocated[u.ResourceName] = u.Allocated
+	}
+
+	require.Equal(t, map[string]uint64{subnetA: 1, subnetB: 1}, allocated)
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
 	"github.com/olekukonko/tablewriter"
@@ -34,15 +37,80 @@ func OutputJSON(w io.Writer, addresses []*gcp.Address) error {
 	return nil
 }
 
-// OutputCSV outputs the addresses as a CSV with address, address_type, resource_type, and resource_name columns
+// OutputCSV outputs the addresses as a CSV with address, address_type, resource_type, resource_name, project, location,
+// display_name, state, create_time, parent_full_resource_name, labels and reference columns. Labels are formatted as
+// key=value pairs separated by semicolons and the reference is only set for unresolved references.
 func OutputCSV(w io.Writer, addresses []*gcp.Address) error {
 	records := [][]string{
-		{"address", "address_type", "resource_type", "resource_name"},
+		{
+			"address", "address_type", "resource_type", "resource_name",
+			"project", "location", "display_name", "state", "create_time", "parent_full_resource_name", "labels", "reference",
+		},
 	}
 	for _, address := range addresses {
-		records = append(records, []string{address.Address, address.AddressType, address.ResourceType, address.ResourceName})
+		records = append(records, []string{
+			address.AddressOrPrefix(), address.AddressType, address.ResourceType, address.ResourceName,
+			address.Project, address.Location, address.DisplayName, address.State, address.CreateTime, address.ParentFullResourceName,
+			formatLabels(address.Labels, ";"), address.Reference,
+		})
 	}
 
+	return writeCSV(w, records)
+}
+
+// OutputTable outputs the IP addresses as a table with Address, Address Type, Resource Type, Resource Name, Project, Location,
+// Display Name, State, Create Time, Parent and Labels columns. Unresolved references show the resource they reference in
+// the Address column.
+func OutputTable(w io.Writer, addresses []*gcp.Address) error {
+	rows := [][]string{}
+
+	for _, addr := range addresses {
+		address := addr.AddressOrPrefix()
+		if addr.AddressType == gcp.AddressTypeUnresolved {
+			address = addr.Reference
+		}
+
+		rows = append(rows, []string{
+			address, addr.AddressType, addr.ResourceType, addr.ResourceName,
+			addr.Project, addr.Location, addr.DisplayName, addr.State, addr.CreateTime, addr.ParentFullResourceName,
+			formatLabels(addr.Labels, ", "),
+		})
+	}
+
+	return writeTable(w, []string{
+		"Address", "Address Type", "Resource Type", "Resource Name",
+		"Project", "Location", "Display Name", "State", "Create Time", "Parent", "Labels",
+	}, rows)
+}
+
+// OutputList outputs the IP addresses as a list, one per line (ranges are output in CIDR notation)
+func OutputList(w io.Writer, addresses []*gcp.Address) error {
+	for _, addr := range addresses {
+		// Unresolved references don't have an address to list
+		if addr.AddressOrPrefix() == "" {
+			continue
+		}
+
+		_, err := fmt.Fprintf(w, "%s\n", addr.AddressOrPrefix())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatLabels formats labels as key=value pairs sorted by key and joined by sep
+func formatLabels(labels map[string]string, sep string) string {
+	pairs := []string{}
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, sep)
+}
+
+// writeCSV writes the records (including the header) as a CSV
+func writeCSV(w io.Writer, records [][]string) error {
 	cw := csv.NewWriter(w)
 	if err := cw.WriteAll(records); err != nil {
 		return fmt.Errorf("error writing csv: %w", err)
@@ -57,28 +125,21 @@ func OutputCSV(w io.Writer, addresses []*gcp.Address) error {
 	return nil
 }
 
-// OutputTable outputs the IP addresses as a table with Address, Address Type, Resource Type, and Resource Name columns
-func OutputTable(w io.Writer, addresses []*gcp.Address) error {
+// writeTable writes the rows as a table with the given header
+func writeTable(w io.Writer, header []string, rows [][]string) error {
 	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"Address", "Address Type", "Resource Type", "Resource Name"})
-
-	for _, addr := range addresses {
-		table.Append([]string{addr.Address, addr.AddressType, addr.ResourceType, addr.ResourceName})
-	}
-
+	table.SetHeader(header)
+	table.AppendBulk(rows)
 	table.Render()
 
 	return nil
 }
 
-// OutputList outputs the IP addresses as a list, one per line
-func OutputList(w io.Writer, addresses []*gcp.Address) error {
-	for _, addr := range addresses {
-		_, err := fmt.Fprintf(w, "%s\n", addr.Address)
-		if err != nil {
-			return err
-		}
+// writeJSON writes the value as a JSON object wrapped under the given key
+func writeJSON(w io.Writer, key string, value any) error {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(map[string]any{key: value}); err != nil {
+		return fmt.Errorf("error writing json: %w", err)
 	}
-
 	return nil
 }
@@ -11,10 +11,17 @@ import (
 
 var testAddresses = []*gcp.Address{
 	{
-		Address:      "1.2.3.4",
-		AddressType:  gcp.AddressTypePublic,
-		ResourceType: "compute.googleapis.com/Instance",
-		ResourceName: "//compute.googleapis.com/instance-1",
+		Address:                "1.2.3.4",
+		AddressType:            gcp.AddressTypePublic,
+		ResourceType:           "compute.googleapis.com/Instance",
+		ResourceName:           "//compute.googleapis.com/instance-1",
+		Project:                "projects/123456",
+		Location:               "us-west1-a",
+		DisplayName:            "instance-1",
+		State:                  "RUNNING",
+		CreateTime:             "2024-07-01T16:16:26Z",
+		Labels:                 map[string]string{"team": "platform", "env": "test"},
+		ParentFullResourceName: "//cloudresourcemanager.googleapis.com/projects/sample-project",
 	},
 	{
 		Address:      "5.6.7.8",
@@ -24,6 +31,13 @@ var testAddresses = []*gcp.Address{
 	},
 }
 
+var unresolvedAddress = &gcp.Address{
+	AddressType:  gcp.AddressTypeUnresolved,
+	ResourceType: "compute.googleapis.com/Router",
+	ResourceName: "//compute.googleapis.com/router-1",
+	Reference:    "https://www.googleapis.com/compute/v1/projects/p/regions/us-west1/addresses/address-1",
+}
+
 func TestOutputCSV(t *testing.T) {
 	buf := bytes.NewBuffer(nil)
 
@@ -31,7 +45,44 @@ func TestOutputCSV(t *testing.T) {
 	require.NoError(t, err)
 
 	output := buf.String()
-	require.Equal(t, "address,address_type,resource_type,resource_name\n1.2.3.4,public,compute.googleapis.com/Instance,//compute.googleapis.com/instance-1\n5.6.7.8,public,sqladmin.googleapis.com/Instance,//sqladmin.googleapis.com/instance-2\n", output)
+	require.Equal(t, "address,address_type,resource_type,resource_name,project,location,display_name,state,create_time,parent_full_resource_name,labels,reference\n"+
+		"1.2.3.4,public,compute.googleapis.com/Instance,//compute.googleapis.com/instance-1,projects/123456,us-west1-a,instance-1,RUNNING,2024-07-01T16:16:26Z,//cloudresourcemanager.googleapis.com/projects/sample-project,env=test;team=platform,\n"+
+		"5.6.7.8,public,sqladmin.googleapis.com/Instance,//sqladmin.googleapis.com/instance-2,,,,,,,,\n", output)
+}
+
+func TestOutputCSVWithUnresolvedReferences(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := output.OutputCSV(buf, []*gcp.Address{unresolvedAddress})
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Equal(t, "address,address_type,resource_type,resource_name,project,location,display_name,state,create_time,parent_full_resource_name,labels,reference\n"+
+		",unresolved,compute.googleapis.com/Router,//compute.googleapis.com/router-1,,,,,,,,https://www.googleapis.com/compute/v1/projects/p/regions/us-west1/addresses/address-1\n", output)
+}
+
+func TestOutputTable(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := output.OutputTable(buf, testAddresses)
+	require.NoError(t, err)
+
+	output := buf.String()
+	for _, header := range []string{"PROJECT", "LOCATION", "DISPLAY NAME", "STATE", "CREATE TIME", "PARENT", "LABELS"} {
+		require.Contains(t, output, header)
+	}
+	for _, value := range []string{"projects/123456", "us-west1-a", "instance-1", "RUNNING", "2024-07-01T16:16:26Z", "//cloudresourcemanager.googleapis.com/projects/sample-project", "env=test, team=platform"} {
+		require.Contains(t, output, value)
+	}
+}
+
+func TestOutputTableWithUnresolvedReferences(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := output.OutputTable(buf, []*gcp.Address{unresolvedAddress})
+	require.NoError(t, err)
+
+	require.Contains(t, buf.String(), "https://www.googleapis.com/compute/v1/projects/p/regions/us-west1/addresses/address-1")
 }
 
 func TestOutputList(t *testing.T) {
@@ -43,3 +94,33 @@ func TestOutputList(t *testing.T) {
 	output := buf.String()
 	require.Equal(t, "1.2.3.4\n5.6.7.8\n", output)
 }
+
+func TestOutputListWithRanges(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	addresses := append(testAddresses, &gcp.Address{
+		Address:      "10.84.0.0",
+		Prefix:       "10.84.0.0/14",
+		AddressType:  gcp.AddressTypePrivate,
+		ResourceType: "container.googleapis.com/Cluster",
+		ResourceName: "//container.googleapis.com/cluster-1",
+	})
+
+	err := output.OutputList(buf, addresses)
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Equal(t, "1.2.3.4\n5.6.7.8\n10.84.0.0/14\n", output)
+}
+
+func TestOutputListWithUnresolvedReferences(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	addresses := append(testAddresses, unresolvedAddress)
+
+	err := output.OutputList(buf, addresses)
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Equal(t, "1.2.3.4\n5.6.7.8\n", output)
+}
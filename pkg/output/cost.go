@@ -0,0 +1,67 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+)
+
+type CostFormatterFunc func(w io.Writer, costs []*gcp.AddressCost) error
+
+func GetCostFormatters() map[string]CostFormatterFunc {
+	return map[string]CostFormatterFunc{
+		"csv":   OutputCostsCSV,
+		"json":  OutputCostsJSON,
+		"table": OutputCostsTable,
+	}
+}
+
+// OutputCostsJSON outputs the address costs as a JSON array
+func OutputCostsJSON(w io.Writer, costs []*gcp.AddressCost) error {
+	return writeJSON(w, "costs", costs)
+}
+
+// OutputCostsCSV outputs the address costs as a CSV with project, category, count, hourly_price, monthly_cost and currency
+// columns
+func OutputCostsCSV(w io.Writer, costs []*gcp.AddressCost) error {
+	records := [][]string{
+		{"project", "category", "count", "hourly_price", "monthly_cost", "currency"},
+	}
+	for _, cost := range costs {
+		records = append(records, []string{
+			cost.Project,
+			cost.Category,
+			fmt.Sprintf("%d", cost.Count),
+			fmt.Sprintf("%g", cost.HourlyPrice),
+			fmt.Sprintf("%.2f", cost.MonthlyCost),
+			cost.Currency,
+		})
+	}
+
+	return writeCSV(w, records)
+}
+
+// OutputCostsTable outputs the address costs as a table followed by a row with the total count and monthly cost
+func OutputCostsTable(w io.Writer, costs []*gcp.AddressCost) error {
+	rows := [][]string{}
+
+	totalCount, totalCost, currency := 0, 0.0, ""
+	for _, cost := range costs {
+		rows = append(rows, []string{
+			cost.Project,
+			cost.Category,
+			fmt.Sprintf("%d", cost.Count),
+			fmt.Sprintf("%g", cost.HourlyPrice),
+			fmt.Sprintf("%.2f", cost.MonthlyCost),
+			cost.Currency,
+		})
+
+		totalCount += cost.Count
+		totalCost += cost.MonthlyCost
+		currency = cost.Currency
+	}
+	rows = append(rows, []string{"Total", "", fmt.Sprintf("%d", totalCount), "", fmt.Sprintf("%.2f", totalCost), currency})
+
+	return writeTable(w, []string{"Project", "Category", "Count", "Hourly Price", "Monthly Cost", "Currency"}, rows)
+}
@@ -0,0 +1,39 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/mark-adams/gcp-ip-list/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputCostsCSV(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := output.OutputCostsCSV(buf, []*gcp.AddressCost{
+		{
+			Project:     "projects/123456",
+			Category:    gcp.CostCategoryVM,
+			Count:       3,
+			HourlyPrice: 0.005,
+			MonthlyCost: 10.95,
+			Currency:    "USD",
+		},
+		{
+			Project:     "projects/123456",
+			Category:    gcp.CostCategoryIdleStatic,
+			Count:       1,
+			HourlyPrice: 0.01,
+			MonthlyCost: 7.3,
+			Currency:    "USD",
+		},
+	})
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Equal(t, "project,category,count,hourly_price,monthly_cost,currency\n"+
+		"projects/123456,vm,3,0.005,10.95,USD\n"+
+		"projects/123456,idle-static,1,0.01,7.30,USD\n", output)
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+)
+
+type OverlapFormatterFunc func(w io.Writer, overlaps []*gcp.RangeOverlap) error
+
+func GetOverlapFormatters() map[string]OverlapFormatterFunc {
+	return map[string]OverlapFormatterFunc{
+		"csv":   OutputOverlapsCSV,
+		"json":  OutputOverlapsJSON,
+		"table": OutputOverlapsTable,
+	}
+}
+
+// OutputOverlapsJSON outputs the overlapping ranges as a JSON array
+func OutputOverlapsJSON(w io.Writer, overlaps []*gcp.RangeOverlap) error {
+	return writeJSON(w, "overlaps", overlaps)
+}
+
+// OutputOverlapsCSV outputs the overlapping ranges as a CSV with one row per overlapping pair, with prefix, range_name,
+// network, project and resource_name columns for each side of the pair followed by a peered column
+func OutputOverlapsCSV(w io.Writer, overlaps []*gcp.RangeOverlap) error {
+	records := [][]string{
+		{
+			"prefix", "range_name", "network", "project", "resource_name",
+			"other_prefix", "other_range_name", "other_network", "other_project", "other_resource_name",
+			"peered",
+		},
+	}
+	for _, overlap := range overlaps {
+		records = append(records, []string{
+			overlap.Prefix, overlap.RangeName, overlap.Network, overlap.Project, overlap.ResourceName,
+			overlap.OtherPrefix, overlap.OtherRangeName, overlap.OtherNetwork, overlap.OtherProject, overlap.OtherResourceName,
+			fmt.Sprintf("%t", overlap.Peered),
+		})
+	}
+
+	return writeCSV(w, records)
+}
+
+// OutputOverlapsTable outputs the overlapping ranges as a table. The network and resource columns only show the
+// last path segment of each name to keep the table readable.
+func OutputOverlapsTable(w io.Writer, overlaps []*gcp.RangeOverlap) error {
+	rows := [][]string{}
+	for _, overlap := range overlaps {
+		rows = append(rows, []string{
+			overlap.Prefix, overlap.Project, shortName(overlap.Network), shortName(overlap.ResourceName),
+			overlap.OtherPrefix, overlap.OtherProject, shortName(overlap.OtherNetwork), shortName(overlap.OtherResourceName),
+			fmt.Sprintf("%t", overlap.Peered),
+		})
+	}
+
+	return writeTable(w, []string{
+		"Prefix", "Project", "Network", "Resource",
+		"Other Prefix", "Other Project", "Other Network", "Other Resource",
+		"Peered",
+	}, rows)
+}
+
+// shortName returns the last path segment of a full resource name
+func shortName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,34 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/mark-adams/gcp-ip-list/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputOverlapsCSV(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := output.OutputOverlapsCSV(buf, []*gcp.RangeOverlap{
+		{
+			Prefix:            "10.0.0.0/16",
+			ResourceName:      "//compute.googleapis.com/subnet-1",
+			Network:           "//compute.googleapis.com/network-1",
+			Project:           "project-1",
+			OtherPrefix:       "10.0.3.0/24",
+			OtherRangeName:    "aliases",
+			OtherResourceName: "//compute.googleapis.com/subnet-2",
+			OtherNetwork:      "//compute.googleapis.com/network-2",
+			OtherProject:      "project-2",
+			Peered:            true,
+		},
+	})
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Equal(t, "prefix,range_name,network,project,resource_name,other_prefix,other_range_name,other_network,other_project,other_resource_name,peered\n"+
+		"10.0.0.0/16,,//compute.googleapis.com/network-1,project-1,//compute.googleapis.com/subnet-1,10.0.3.0/24,aliases,//compute.googleapis.com/network-2,project-2,//compute.googleapis.com/subnet-2,true\n", output)
+}
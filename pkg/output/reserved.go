@@ -0,0 +1,48 @@
+package output
+
+import (
+	"io"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+)
+
+func GetReservedFormatters() map[string]FormatterFunc {
+	return map[string]FormatterFunc{
+		"csv":   OutputReservedCSV,
+		"json":  OutputReservedJSON,
+		"table": OutputReservedTable,
+		"list":  OutputList,
+	}
+}
+
+// OutputReservedJSON outputs the reserved addresses as a JSON array
+func OutputReservedJSON(w io.Writer, addresses []*gcp.Address) error {
+	return writeJSON(w, "addresses", addresses)
+}
+
+// OutputReservedCSV outputs the reserved addresses as a CSV with address, project, region, network_tier, create_time
+// and resource_name columns
+func OutputReservedCSV(w io.Writer, addresses []*gcp.Address) error {
+	records := [][]string{
+		{"address", "project", "region", "network_tier", "create_time", "resource_name"},
+	}
+	for _, addr := range addresses {
+		records = append(records, []string{
+			addr.Address, addr.Project, addr.Location, addr.NetworkTier, addr.CreateTime, addr.ResourceName,
+		})
+	}
+
+	return writeCSV(w, records)
+}
+
+// OutputReservedTable outputs the reserved addresses as a table
+func OutputReservedTable(w io.Writer, addresses []*gcp.Address) error {
+	rows := [][]string{}
+	for _, addr := range addresses {
+		rows = append(rows, []string{
+			addr.Address, addr.Project, addr.Location, addr.NetworkTier, addr.CreateTime, shortName(addr.ResourceName),
+		})
+	}
+
+	return writeTable(w, []string{"Address", "Project", "Region", "Network Tier", "Created", "Resource"}, rows)
+}
@@ -0,0 +1,33 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/mark-adams/gcp-ip-list/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputReservedCSV(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := output.OutputReservedCSV(buf, []*gcp.Address{
+		{
+			Address:      "34.19.68.170",
+			AddressType:  gcp.AddressTypePublic,
+			ResourceType: gcp.AssetTypeComputeAddress,
+			ResourceName: "//compute.googleapis.com/address-1",
+			Project:      "projects/123456",
+			Location:     "us-west1",
+			NetworkTier:  "PREMIUM",
+			CreateTime:   "2024-07-01T16:16:37Z",
+			State:        "RESERVED",
+		},
+	})
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Equal(t, "address,project,region,network_tier,create_time,resource_name\n"+
+		"34.19.68.170,projects/123456,us-west1,PREMIUM,2024-07-01T16:16:37Z,//compute.googleapis.com/address-1\n", output)
+}
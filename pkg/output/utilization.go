@@ -0,0 +1,59 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+)
+
+type UtilizationFormatterFunc func(w io.Writer, subnets []*gcp.SubnetUtilization) error
+
+func GetUtilizationFormatters() map[string]UtilizationFormatterFunc {
+	return map[string]UtilizationFormatterFunc{
+		"csv":   OutputUtilizationCSV,
+		"json":  OutputUtilizationJSON,
+		"table": OutputUtilizationTable,
+	}
+}
+
+// OutputUtilizationJSON outputs the subnet utilization as a JSON array
+func OutputUtilizationJSON(w io.Writer, subnets []*gcp.SubnetUtilization) error {
+	return writeJSON(w, "subnets", subnets)
+}
+
+// OutputUtilizationCSV outputs the subnet utilization as a CSV with prefix, range_name, allocated, capacity, utilization_percent,
+// largest_free_blocks, and resource_name columns. Free blocks are separated by spaces.
+func OutputUtilizationCSV(w io.Writer, subnets []*gcp.SubnetUtilization) error {
+	records := [][]string{
+		{"prefix", "range_name", "allocated", "capacity", "utilization_percent", "largest_free_blocks", "resource_name"},
+	}
+	for _, subnet := range subnets {
+		records = append(records, utilizationRow(subnet, " "))
+	}
+
+	return writeCSV(w, records)
+}
+
+// OutputUtilizationTable outputs the subnet utilization as a table
+func OutputUtilizationTable(w io.Writer, subnets []*gcp.SubnetUtilization) error {
+	rows := [][]string{}
+	for _, subnet := range subnets {
+		rows = append(rows, utilizationRow(subnet, ", "))
+	}
+
+	return writeTable(w, []string{"Prefix", "Range Name", "Allocated", "Capacity", "Utilization", "Largest Free Blocks", "Resource Name"}, rows)
+}
+
+func utilizationRow(subnet *gcp.SubnetUtilization, sep string) []string {
+	return []string{
+		subnet.Prefix,
+		subnet.RangeName,
+		fmt.Sprintf("%d", subnet.Allocated),
+		fmt.Sprintf("%d", subnet.Capacity),
+		fmt.Sprintf("%.1f", subnet.Utilization),
+		strings.Join(subnet.LargestFreeBlocks, sep),
+		subnet.ResourceName,
+	}
+}
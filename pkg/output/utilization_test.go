@@ -0,0 +1,29 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mark-adams/gcp-ip-list/pkg/gcp"
+	"github.com/mark-adams/gcp-ip-list/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputUtilizationCSV(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := output.OutputUtilizationCSV(buf, []*gcp.SubnetUtilization{
+		{
+			ResourceName:      "//compute.googleapis.com/subnet-1",
+			Prefix:            "10.0.3.0/24",
+			Allocated:         2,
+			Capacity:          252,
+			Utilization:       float64(2) / 252 * 100,
+			LargestFreeBlocks: []string{"10.0.3.64/26", "10.0.3.128/26"},
+		},
+	})
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Equal(t, "prefix,range_name,allocated,capacity,utilization_percent,largest_free_blocks,resource_name\n10.0.3.0/24,,2,252,0.8,10.0.3.64/26 10.0.3.128/26,//compute.googleapis.com/subnet-1\n", output)
+}
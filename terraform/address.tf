@@ -3,3 +3,11 @@ resource "google_compute_address" "default" {
   address_type = "EXTERNAL"
   region       = google_compute_router.default.region
 }
+
+resource "google_compute_address" "pupi" {
+  name         = "${local.prefix}-pupi-address"
+  address_type = "INTERNAL"
+  address      = "11.0.0.10"
+  subnetwork   = google_compute_subnetwork.pupi_subnet.id
+  region       = var.region
+}
@@ -0,0 +1,47 @@
+resource "google_alloydb_cluster" "default" {
+  cluster_id = "${local.prefix}-alloydb"
+  location   = var.region
+
+  network_config {
+    network = google_compute_network.default.id
+  }
+
+  initial_user {
+    password = "ip-list-test-password"
+  }
+
+  deletion_policy = "FORCE"
+
+  depends_on = [google_service_networking_connection.cloudsql]
+}
+
+resource "google_alloydb_instance" "primary" {
+  cluster       = google_alloydb_cluster.default.name
+  instance_id   = "${local.prefix}-alloydb-primary"
+  instance_type = "PRIMARY"
+
+  machine_config {
+    cpu_count = 2
+  }
+
+  network_config {
+    enable_public_ip          = true
+    enable_outbound_public_ip = true
+  }
+}
+
+resource "google_alloydb_instance" "read_pool" {
+  cluster       = google_alloydb_cluster.default.name
+  instance_id   = "${local.prefix}-alloydb-read-pool"
+  instance_type = "READ_POOL"
+
+  read_pool_config {
+    node_count = 1
+  }
+
+  machine_config {
+    cpu_count = 2
+  }
+
+  depends_on = [google_alloydb_instance.primary]
+}
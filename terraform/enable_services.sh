@@ -3,7 +3,10 @@
 # This script is useful for enabling the required services in GCP before running `terraform apply` the first time
 
 gcloud services enable --project=$1 \
+    alloydb.googleapis.com \
     cloudresourcemanager.googleapis.com \
     container.googleapis.com \
     compute.googleapis.com \
+    file.googleapis.com \
+    redis.googleapis.com \
     servicenetworking.googleapis.com
\ No newline at end of file
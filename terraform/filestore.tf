@@ -0,0 +1,15 @@
+resource "google_filestore_instance" "default" {
+  name     = "${local.prefix}-filestore"
+  location = "${var.region}-a"
+  tier     = "BASIC_HDD"
+
+  file_shares {
+    capacity_gb = 1024
+    name        = "share1"
+  }
+
+  networks {
+    network = google_compute_network.default.name
+    modes   = ["MODE_IPV4"]
+  }
+}
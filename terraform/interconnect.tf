@@ -0,0 +1,19 @@
+# Partner Interconnect attachments get link-local addresses for the Cloud Router and customer router BGP session
+resource "google_compute_router" "interconnect" {
+  name    = "${local.prefix}-interconnect-router"
+  region  = var.region
+  network = google_compute_network.default.id
+
+  bgp {
+    asn = 16550
+  }
+}
+
+resource "google_compute_interconnect_attachment" "default" {
+  name                     = "${local.prefix}-attachment"
+  region                   = var.region
+  type                     = "PARTNER"
+  router                   = google_compute_router.interconnect.id
+  edge_availability_domain = "AVAILABILITY_DOMAIN_1"
+  mtu                      = 1440
+}
@@ -0,0 +1,62 @@
+# This file creates a dual-stack VM and a regional forwarding rule with an external IPv6 address
+
+resource "google_compute_instance" "ipv6" {
+  name         = "${local.prefix}-vm-ipv6"
+  machine_type = "f1-micro"
+  zone         = "${var.region}-a"
+
+  boot_disk {
+    initialize_params {
+      image = "debian-cloud/debian-12"
+    }
+  }
+
+  network_interface {
+    network    = google_compute_network.default.id
+    subnetwork = google_compute_subnetwork.backend_subnet.id
+    stack_type = "IPV4_IPV6"
+
+    access_config {
+      // Ephemeral public IP
+    }
+
+    ipv6_access_config {
+      network_tier = "PREMIUM"
+    }
+
+    alias_ip_range {
+      ip_cidr_range         = "10.10.0.16/28"
+      subnetwork_range_name = "backend-subnet-aliases"
+    }
+  }
+
+  deletion_protection = false
+}
+
+resource "google_compute_forwarding_rule" "ipv6" {
+  name                  = "${local.prefix}-forwarding-rule-ipv6"
+  region                = var.region
+  ip_protocol           = "TCP"
+  ip_version            = "IPV6"
+  load_balancing_scheme = "EXTERNAL"
+  port_range            = "80"
+  subnetwork            = google_compute_subnetwork.backend_subnet.id
+  backend_service       = google_compute_region_backend_service.ipv6.id
+}
+
+resource "google_compute_region_backend_service" "ipv6" {
+  name                  = "${local.prefix}-backend-service-ipv6"
+  region                = var.region
+  protocol              = "TCP"
+  load_balancing_scheme = "EXTERNAL"
+  health_checks         = [google_compute_region_health_check.ipv6.id]
+}
+
+resource "google_compute_region_health_check" "ipv6" {
+  name   = "${local.prefix}-health-check-ipv6"
+  region = var.region
+
+  tcp_health_check {
+    port = 80
+  }
+}
@@ -32,4 +32,37 @@ resource "google_compute_subnetwork" "backend_subnet" {
   ip_cidr_range = "10.0.3.0/24"
   region        = var.region
   network       = google_compute_network.default.id
-}
\ No newline at end of file
+
+  stack_type       = "IPV4_IPV6"
+  ipv6_access_type = "EXTERNAL"
+
+  secondary_ip_range {
+    range_name    = "backend-subnet-aliases"
+    ip_cidr_range = "10.10.0.0/20"
+  }
+}
+# A second network whose ranges overlap the ones above, used to exercise the overlaps report
+resource "google_compute_network" "overlap" {
+  name                    = "ip-list-test-overlap-network"
+  auto_create_subnetworks = false
+}
+
+resource "google_compute_subnetwork" "overlap_subnet" {
+  name          = "ip-list-test-overlap-subnet"
+  ip_cidr_range = "10.0.0.0/16"
+  region        = var.region
+  network       = google_compute_network.overlap.id
+
+  secondary_ip_range {
+    range_name    = "ip-list-test-overlap-pods"
+    ip_cidr_range = "10.86.0.0/16"
+  }
+}
+
+# A subnet using a public range privately (PUPI), used to exercise -private-ranges and -detect-pupi
+resource "google_compute_subnetwork" "pupi_subnet" {
+  name          = "ip-list-test-pupi-subnet"
+  ip_cidr_range = "11.0.0.0/24"
+  region        = var.region
+  network       = google_compute_network.default.id
+}
@@ -0,0 +1,12 @@
+resource "google_redis_instance" "default" {
+  name           = "${local.prefix}-redis"
+  tier           = "STANDARD_HA"
+  memory_size_gb = 1
+  region         = var.region
+
+  authorized_network = google_compute_network.default.id
+  redis_version      = "REDIS_7_0"
+
+  replica_count      = 1
+  read_replicas_mode = "READ_REPLICAS_ENABLED"
+}
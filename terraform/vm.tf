@@ -18,5 +18,10 @@ resource "google_compute_instance" "default" {
     }
   }
 
+  labels = {
+    env  = "test"
+    team = "platform"
+  }
+
   deletion_protection = false
 }
\ No newline at end of file
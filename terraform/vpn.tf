@@ -0,0 +1,44 @@
+# HA VPN gateways get their public IPs assigned directly to their interfaces
+resource "google_compute_ha_vpn_gateway" "default" {
+  name    = "${local.prefix}-ha-vpn"
+  region  = var.region
+  network = google_compute_network.default.id
+}
+
+# Classic VPN gateways get their public IP from the forwarding rules that point at them
+resource "google_compute_vpn_gateway" "classic" {
+  name    = "${local.prefix}-classic-vpn"
+  region  = var.region
+  network = google_compute_network.default.id
+}
+
+resource "google_compute_address" "classic_vpn" {
+  name   = "${local.prefix}-classic-vpn"
+  region = var.region
+}
+
+resource "google_compute_forwarding_rule" "classic_vpn_esp" {
+  name        = "${local.prefix}-classic-vpn-esp"
+  region      = var.region
+  ip_protocol = "ESP"
+  ip_address  = google_compute_address.classic_vpn.address
+  target      = google_compute_vpn_gateway.classic.id
+}
+
+resource "google_compute_forwarding_rule" "classic_vpn_udp500" {
+  name        = "${local.prefix}-classic-vpn-udp500"
+  region      = var.region
+  ip_protocol = "UDP"
+  port_range  = "500"
+  ip_address  = google_compute_address.classic_vpn.address
+  target      = google_compute_vpn_gateway.classic.id
+}
+
+resource "google_compute_forwarding_rule" "classic_vpn_udp4500" {
+  name        = "${local.prefix}-classic-vpn-udp4500"
+  region      = var.region
+  ip_protocol = "UDP"
+  port_range  = "4500"
+  ip_address  = google_compute_address.classic_vpn.address
+  target      = google_compute_vpn_gateway.classic.id
+}